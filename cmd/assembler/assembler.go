@@ -17,8 +17,15 @@ package assembler
 
 import (
 	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/cmd/drift"
+	"github.com/ealebed/tfctl/cmd/graph"
 	"github.com/ealebed/tfctl/cmd/oauth_client"
 	"github.com/ealebed/tfctl/cmd/policy_set"
+	"github.com/ealebed/tfctl/cmd/project"
+	"github.com/ealebed/tfctl/cmd/queue"
+	"github.com/ealebed/tfctl/cmd/run"
+	"github.com/ealebed/tfctl/cmd/runtask"
+	"github.com/ealebed/tfctl/cmd/tag"
 	"github.com/ealebed/tfctl/cmd/variable"
 	"github.com/ealebed/tfctl/cmd/workspace"
 
@@ -32,4 +39,11 @@ func AddSubCommands(rootCmd *cobra.Command, rootOpts *cmd.RootOptions) {
 	rootCmd.AddCommand(variable.NewVariableCmd(rootOpts))
 	rootCmd.AddCommand(policy_set.NewPolicySetCmd(rootOpts))
 	rootCmd.AddCommand(oauth_client.NewOAuthClientCmd(rootOpts))
+	rootCmd.AddCommand(tag.NewTagCmd(rootOpts))
+	rootCmd.AddCommand(project.NewProjectCmd(rootOpts))
+	rootCmd.AddCommand(run.NewRunCmd(rootOpts))
+	rootCmd.AddCommand(runtask.NewRunTaskCmd(rootOpts))
+	rootCmd.AddCommand(graph.NewGraphCmd(rootOpts))
+	rootCmd.AddCommand(drift.NewDriftCmd(rootOpts))
+	rootCmd.AddCommand(queue.NewQueueCmd(rootOpts))
 }
@@ -0,0 +1,50 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"github.com/ealebed/tfctl/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+// Package describes drift detection across workspaces with refresh-only plans
+// and health assessments that the Terraform Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/assessment-results
+
+type driftOptions struct {
+	*cmd.RootOptions
+}
+
+// NewDriftCmd create new drift command
+func NewDriftCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &driftOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:     "drift",
+		Short:   "Detect drift of terraform workspaces",
+		Long:    "Detect resources changed outside of terraform in workspaces",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewDriftScanCmd(options))
+
+	return cobraCmd
+}
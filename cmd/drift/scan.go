@@ -0,0 +1,325 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/pkg/drift"
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/planjson"
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+const (
+	modeAuto        = "auto"
+	modeAssessment  = "assessment"
+	modeRefreshOnly = "refresh-only"
+
+	// exitDrifted is the exit code of the scan finding drift with --fail-on-drift
+	exitDrifted = 2
+)
+
+// scanOptions represents options for scan command
+type scanOptions struct {
+	*driftOptions
+	selector    string
+	mode        string
+	format      string
+	outputFile  string
+	timeout     time.Duration
+	workers     int
+	failOnDrift bool
+}
+
+// assessmentResult represents the current health assessment result of a workspace
+type assessmentResult struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Drifted   bool      `json:"drifted"`
+			Succeeded bool      `json:"succeeded"`
+			ErrorMsg  string    `json:"error-msg"`
+			CreatedAt time.Time `json:"created-at"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+// NewDriftScanCmd returns new drift scan command
+func NewDriftScanCmd(driftOptions *driftOptions) *cobra.Command {
+	options := &scanOptions{
+		driftOptions: driftOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "scan",
+		Short: "scan workspaces for drift and report drifted resources",
+		Long: "scan all (or selected) workspaces for drift concurrently: read the latest health assessment result " +
+			"or queue a refresh-only plan and wait for it, then report drifted resources per workspace",
+		Example: "tfctl drift scan [--selector=...] [--mode=auto|assessment|refresh-only] [--format=table|json|markdown|junit] [--output=...]",
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return scanDrift(cobraCmd, options)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"Optional: workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cobraCmd.Flags().StringVar(&options.mode, "mode", modeAuto,
+		"Optional: Use health assessment results (assessment), refresh-only plans (refresh-only) "+
+			"or assessments when they are enabled and refresh-only plans otherwise (auto)")
+	cobraCmd.Flags().StringVarP(&options.format, "format", "f", "table", "Optional: Report format (table, json, markdown or junit)")
+	cobraCmd.Flags().StringVarP(&options.outputFile, "output", "o", "", "Optional: Write json, markdown or junit report to the file instead of stdout")
+	cobraCmd.Flags().DurationVar(&options.timeout, "timeout", 30*time.Minute, "Optional: Maximum time to wait for a refresh-only plan of a workspace")
+	cobraCmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of workspaces scanned concurrently")
+	cobraCmd.Flags().BoolVar(&options.failOnDrift, "fail-on-drift", false,
+		fmt.Sprintf("Optional: Exit with code %d if any workspace has drifted", exitDrifted))
+
+	return cobraCmd
+}
+
+func scanDrift(cobraCmd *cobra.Command, options *scanOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := utils.ValidateOneOf("mode", options.mode, modeAuto, modeAssessment, modeRefreshOnly); err != nil {
+		return err
+	}
+	if err := utils.ValidateOneOf("format", options.format, "table", "json", "markdown", "junit"); err != nil {
+		return err
+	}
+
+	// All the workspaces of the organization are used if selector is not set
+	sel, err := selector.ParseOptional(options.selector)
+	if err != nil {
+		return err
+	}
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	results := make([]drift.Result, 0, len(workspaces))
+	worker.Run(ctx, workspaces, worker.Options{Workers: options.workers}, func(ctx context.Context, workspace *tfe.Workspace) error {
+		result := scanWorkspace(ctx, options, workspace)
+		fmt.Fprintf(cobraCmd.ErrOrStderr(), "%s: %s %s\n", workspace.Name, result.Status, result.Message)
+
+		mu.Lock()
+		defer mu.Unlock()
+		results = append(results, result)
+		return nil
+	})
+
+	report := drift.NewReport(results, time.Now())
+	if err := writeReport(report, options); err != nil {
+		return err
+	}
+
+	if options.failOnDrift && report.Summary.Drifted > 0 {
+		return &cmd.ExitError{Code: exitDrifted, Err: fmt.Errorf("%d workspace(s) drifted", report.Summary.Drifted)}
+	}
+
+	return nil
+}
+
+// scanWorkspace detects drift of a workspace according to the scan mode
+func scanWorkspace(ctx context.Context, options *scanOptions, workspace *tfe.Workspace) drift.Result {
+	if workspace.ExecutionMode == "local" {
+		return drift.Result{Workspace: workspace.Name, Status: drift.Skipped, Message: "local execution mode"}
+	}
+
+	if options.mode != modeRefreshOnly && workspace.AssessmentsEnabled {
+		result, err := scanAssessment(ctx, options.TClient, workspace)
+		if err == nil {
+			return result
+		}
+		if options.mode == modeAssessment || !errors.Is(err, tfe.ErrResourceNotFound) {
+			return drift.Result{Workspace: workspace.Name, Status: drift.Errored, Source: drift.Assessment, Message: err.Error()}
+		}
+	}
+	if options.mode == modeAssessment {
+		return drift.Result{Workspace: workspace.Name, Status: drift.Skipped, Message: "health assessments are not enabled"}
+	}
+
+	result, err := scanRefreshOnly(ctx, options, workspace)
+	if err != nil {
+		result.Status = drift.Errored
+		result.Message = err.Error()
+	}
+
+	return result
+}
+
+// scanAssessment detects drift of a workspace from its current health assessment result
+func scanAssessment(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace) (drift.Result, error) {
+	result := drift.Result{Workspace: workspace.Name, Source: drift.Assessment}
+
+	var assessment assessmentResult
+	if err := readJSON(ctx, c, "workspaces/"+url.PathEscape(workspace.ID)+"/current-assessment-result", &assessment); err != nil {
+		return result, err
+	}
+	attributes := assessment.Data.Attributes
+
+	switch {
+	case !attributes.Succeeded:
+		result.Status = drift.Errored
+		result.Message = "health assessment failed: " + attributes.ErrorMsg
+		return result, nil
+	case !attributes.Drifted:
+		result.Status = drift.NoDrift
+		result.Message = "assessed at " + attributes.CreatedAt.Local().Format("2006-01-02 15:04:05")
+		return result, nil
+	}
+
+	result.Status = drift.Drifted
+	result.Message = "assessed at " + attributes.CreatedAt.Local().Format("2006-01-02 15:04:05")
+
+	// Assessment result JSON output is a plan with drifted resources
+	var data bytes.Buffer
+	if err := readJSON(ctx, c, "assessment-results/"+url.PathEscape(assessment.Data.ID)+"/json-output", &data); err != nil {
+		result.Message += ", drifted resources are not available: " + err.Error()
+		return result, nil
+	}
+	plan, err := planjson.Parse(data.Bytes())
+	if err != nil {
+		return result, err
+	}
+	result.Resources = plan.Drifted()
+
+	return result, nil
+}
+
+// scanRefreshOnly detects drift of a workspace with a refresh-only speculative plan
+func scanRefreshOnly(ctx context.Context, options *scanOptions, workspace *tfe.Workspace) (drift.Result, error) {
+	c := options.TClient
+	result := drift.Result{Workspace: workspace.Name, Source: drift.RefreshOnly}
+
+	run, err := c.Runs.Create(ctx, tfe.RunCreateOptions{
+		Workspace:   workspace,
+		Message:     tfe.String("Drift scan by tfctl"),
+		RefreshOnly: tfe.Bool(true),
+		PlanOnly:    tfe.Bool(true),
+	})
+	if err != nil {
+		return result, err
+	}
+	result.RunID = run.ID
+	result.URL = runstate.URL(options.TerraformHostname, options.TerraformOrganization, workspace.Name, run.ID)
+
+	waitCtx, cancel := context.WithTimeout(ctx, options.timeout)
+	defer cancel()
+	run, err = runstate.Wait(waitCtx, c, result.RunID, runstate.WaitOptions{UntilFinal: true})
+	if err != nil {
+		// Don't leave the refresh-only plan in the workspace queue, the scan context may be already done
+		if stopErr := runstate.Stop(context.Background(), c, result.RunID, "Drift scan by tfctl is interrupted"); stopErr != nil {
+			err = fmt.Errorf("%v, the run is not stopped: %v", err, stopErr)
+		}
+		return result, err
+	}
+	if run.Status != tfe.RunPlannedAndFinished {
+		return result, fmt.Errorf("refresh-only plan finished with status '%s'", run.Status)
+	}
+
+	data, err := c.Plans.ReadJSONOutput(ctx, run.Plan.ID)
+	if err != nil {
+		return result, err
+	}
+	plan, err := planjson.Parse(data)
+	if err != nil {
+		return result, err
+	}
+
+	result.Resources = plan.Drifted()
+	result.Status = drift.NoDrift
+	if len(result.Resources) > 0 {
+		result.Status = drift.Drifted
+	}
+
+	return result, nil
+}
+
+// readJSON reads the API path to a JSON model or to io.Writer
+func readJSON(ctx context.Context, c *tfe.Client, path string, model any) error {
+	req, err := c.NewRequest("GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	if w, ok := model.(io.Writer); ok {
+		return req.Do(ctx, w)
+	}
+
+	var data bytes.Buffer
+	if err := req.Do(ctx, &data); err != nil {
+		return err
+	}
+	return json.Unmarshal(data.Bytes(), model)
+}
+
+// writeReport writes the report in the requested format to stdout or to the output file
+func writeReport(report *drift.Report, options *scanOptions) error {
+	if options.format == "table" {
+		if options.Expand {
+			output.JsonOutput(report)
+			return nil
+		}
+		rows := make([][]string, 0, len(report.Workspaces))
+		for _, result := range report.Workspaces {
+			rows = append(rows, []string{result.Workspace, string(result.Status), string(result.Source), fmt.Sprint(len(result.Resources)), result.RunID, result.Message})
+		}
+		output.TableOutput([]string{"WORKSPACE", "STATUS", "SOURCE", "DRIFTED", "RUN", "MESSAGE"}, rows)
+		fmt.Printf("\n%s\n", report.Summary)
+		return nil
+	}
+
+	var w io.Writer = os.Stdout
+	if options.outputFile != "" {
+		// #nosec G304 -- path to the output file is provided by the user
+		file, err := os.Create(options.outputFile)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		w = file
+	}
+
+	switch options.format {
+	case "json":
+		return report.WriteJSON(w)
+	case "markdown":
+		return report.WriteMarkdown(w)
+	default:
+		return report.WriteJUnit(w)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/pkg/graph"
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// Package describes the workspace dependency graph built from run triggers
+// and remote state sharing that the Terraform Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run-triggers
+
+// graphOptions represents options for graph command
+type graphOptions struct {
+	*cmd.RootOptions
+	selector    string
+	format      string
+	remoteState bool
+	failOnCycle bool
+	workers     int
+}
+
+// NewGraphCmd returns new graph command
+func NewGraphCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &graphOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Show dependency graph of terraform workspaces",
+		Long: "Show dependency graph of terraform workspaces built from run triggers and remote state consumers " +
+			"in DOT, Mermaid or JSON format, reporting dependency cycles",
+		Example: "tfctl graph [--selector=...] [--format=dot|mermaid|json] [--remote-state=false] [--fail-on-cycle]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showGraph(cmd, options)
+		},
+	}
+
+	cobraCmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"Optional: workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cobraCmd.Flags().StringVarP(&options.format, "format", "f", "dot", "Optional: Output format (dot, mermaid or json)")
+	cobraCmd.Flags().BoolVar(&options.remoteState, "remote-state", true, "Optional: Include remote state consumers in addition to run triggers")
+	cobraCmd.Flags().BoolVar(&options.failOnCycle, "fail-on-cycle", false, "Optional: Return error if the graph contains dependency cycles")
+	cobraCmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of workspaces processed concurrently")
+
+	return cobraCmd
+}
+
+func showGraph(cobraCmd *cobra.Command, options *graphOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := utils.ValidateOneOf("format", options.format, "dot", "mermaid", "json"); err != nil {
+		return err
+	}
+
+	// All the workspaces of the organization are used if selector is not set
+	sel, err := selector.ParseOptional(options.selector)
+	if err != nil {
+		return err
+	}
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+
+	g, err := graph.Build(ctx, c, workspaces, graph.BuildOptions{RemoteState: options.remoteState, Workers: options.workers})
+	if err != nil {
+		return err
+	}
+
+	switch options.format {
+	case "mermaid":
+		err = g.WriteMermaid(cobraCmd.OutOrStdout())
+	case "json":
+		output.JsonOutput(g)
+	default:
+		err = g.WriteDOT(cobraCmd.OutOrStdout())
+	}
+	if err != nil {
+		return err
+	}
+
+	cycles := g.Cycles()
+	for _, cycle := range cycles {
+		fmt.Fprintf(cobraCmd.ErrOrStderr(), "WARNING: dependency cycle between workspaces: %s\n", strings.Join(cycle, ", "))
+	}
+	if options.failOnCycle && len(cycles) > 0 {
+		return fmt.Errorf("dependency graph contains %d cycle(s)", len(cycles))
+	}
+
+	return nil
+}
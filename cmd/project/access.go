@@ -0,0 +1,434 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// accessOptions represents options for project team access commands
+type accessOptions struct {
+	*projectOptions
+	projectName   string
+	teamName      string
+	access        string
+	settings      string
+	teams         string
+	variableSets  string
+	runs          string
+	variables     string
+	stateVersions string
+	sentinelMocks string
+	create        bool
+	locking       bool
+	move          bool
+	delete        bool
+	runTasks      bool
+}
+
+// customAccessFlags are flags allowed only with 'custom' access level
+var customAccessFlags = []string{
+	"settings", "teams", "variable-sets",
+	"runs", "variables", "state-versions", "sentinel-mocks", "create", "locking", "move", "delete", "run-tasks",
+}
+
+// NewProjectAccessCmd returns new project team access command
+func NewProjectAccessCmd(projectOptions *projectOptions) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "access",
+		Aliases: []string{"team-access"},
+		Short:   "manage team access to projects",
+		Long:    "manage team access to projects with built-in access levels (read, write, maintain, admin) or custom permissions",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewProjectAccessListCmd(projectOptions))
+	cobraCmd.AddCommand(NewProjectAccessGrantCmd(projectOptions))
+	cobraCmd.AddCommand(NewProjectAccessSetCmd(projectOptions))
+	cobraCmd.AddCommand(NewProjectAccessRevokeCmd(projectOptions))
+
+	return cobraCmd
+}
+
+// NewProjectAccessListCmd returns new project access list command
+func NewProjectAccessListCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &accessOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list all the teams having access to a project",
+		Long:    "list all the teams having access to a project with their permissions",
+		Example: "tfctl project access list [--project=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listProjectTeamAccess(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "terraform project name")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewProjectAccessGrantCmd returns new project access grant command
+func NewProjectAccessGrantCmd(projectOptions *projectOptions) *cobra.Command {
+	return newProjectAccessSaveCmd(projectOptions, "grant", "grant a team access to a project", false)
+}
+
+// NewProjectAccessSetCmd returns new project access set command
+func NewProjectAccessSetCmd(projectOptions *projectOptions) *cobra.Command {
+	return newProjectAccessSaveCmd(projectOptions, "set", "set (grant or update) team access to a project", true)
+}
+
+func newProjectAccessSaveCmd(projectOptions *projectOptions, use, description string, update bool) *cobra.Command {
+	options := &accessOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   description,
+		Long:    description + ". Permissions flags are allowed only with 'custom' access level",
+		Example: "tfctl project access " + use + " [--project=...] [--team=...] [--access=maintain|custom] [--settings=update] [--runs=apply] [--create]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return saveProjectTeamAccess(cmd, options, update)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "terraform project name")
+	cmd.Flags().StringVarP(&options.teamName, "team", "t", "", "terraform team name")
+	cmd.Flags().StringVarP(&options.access, "access", "a", "", "access level (read, write, maintain, admin or custom)")
+	cmd.Flags().StringVar(&options.settings, "settings", "", "Optional: Custom project settings permission (read, update or delete)")
+	cmd.Flags().StringVar(&options.teams, "teams", "", "Optional: Custom project teams permission (none, read or manage)")
+	cmd.Flags().StringVar(&options.variableSets, "variable-sets", "", "Optional: Custom project variable sets permission (none, read or write)")
+	cmd.Flags().StringVar(&options.runs, "runs", "", "Optional: Custom workspaces runs permission (read, plan or apply)")
+	cmd.Flags().StringVar(&options.variables, "variables", "", "Optional: Custom workspaces variables permission (none, read or write)")
+	cmd.Flags().StringVar(&options.stateVersions, "state-versions", "",
+		"Optional: Custom workspaces state versions permission (none, read-outputs, read or write)")
+	cmd.Flags().StringVar(&options.sentinelMocks, "sentinel-mocks", "", "Optional: Custom workspaces Sentinel mocks permission (none or read)")
+	cmd.Flags().BoolVar(&options.create, "create", false, "Optional: Custom permission to create workspaces in the project")
+	cmd.Flags().BoolVar(&options.locking, "locking", false, "Optional: Custom permission to lock and unlock workspaces")
+	cmd.Flags().BoolVar(&options.move, "move", false, "Optional: Custom permission to move workspaces out of the project")
+	cmd.Flags().BoolVar(&options.delete, "delete", false, "Optional: Custom permission to delete workspaces")
+	cmd.Flags().BoolVar(&options.runTasks, "run-tasks", false, "Optional: Custom permission to manage run tasks")
+	for _, flag := range []string{"project", "team", "access"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			return nil
+		}
+	}
+
+	return cmd
+}
+
+// NewProjectAccessRevokeCmd returns new project access revoke command
+func NewProjectAccessRevokeCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &accessOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "revoke",
+		Aliases: []string{"rm"},
+		Short:   "revoke team access to a project",
+		Long:    "revoke team access to a project",
+		Example: "tfctl project access revoke [--project=...] [--team=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return revokeProjectTeamAccess(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "terraform project name")
+	cmd.Flags().StringVarP(&options.teamName, "team", "t", "", "terraform team name")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("team"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func listProjectTeamAccess(_ *cobra.Command, options *accessOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	project, err := readProject(ctx, c, options.TerraformOrganization, options.projectName)
+	if err != nil {
+		return err
+	}
+
+	// List all the team accesses for a given project
+	accesses, err := listProjectAccesses(ctx, c, project.ID)
+	if err != nil {
+		return err
+	}
+
+	if options.Expand {
+		output.JsonOutput(accesses)
+		return nil
+	}
+
+	var rows [][]string
+	for _, access := range accesses {
+		team, err := c.Teams.Read(ctx, access.Team.ID)
+		if err != nil {
+			return err
+		}
+		row := []string{team.Name, string(access.Access), "", "", "", "", "", "", ""}
+		if p := access.ProjectAccess; p != nil {
+			row[2], row[3], row[4] = string(p.ProjectSettingsPermission), string(p.ProjectTeamsPermission), string(p.ProjectVariableSetsPermission)
+		}
+		if w := access.WorkspaceAccess; w != nil {
+			row[5], row[6], row[7], row[8] = string(w.WorkspaceRunsPermission), string(w.WorkspaceVariablesPermission),
+				string(w.WorkspaceStateVersionsPermission), string(w.WorkspaceSentinelMocksPermission)
+		}
+		rows = append(rows, row)
+	}
+	output.TableOutput([]string{"TEAM", "ACCESS", "SETTINGS", "TEAMS", "VARIABLE-SETS", "RUNS", "VARIABLES", "STATE-VERSIONS", "SENTINEL-MOCKS"}, rows)
+
+	return nil
+}
+
+func saveProjectTeamAccess(cmd *cobra.Command, options *accessOptions, update bool) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := validateProjectTeamAccess(cmd, options); err != nil {
+		return err
+	}
+
+	project, teamID, existing, err := findProjectTeamAccess(ctx, c, options)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && !update {
+		return fmt.Errorf("team '%s' already has '%s' access to project '%s', use 'tfctl project access set' to change it",
+			options.teamName, existing.Access, options.projectName)
+	}
+
+	access := tfe.TeamProjectAccessType(options.access)
+	var projectAccess *tfe.TeamProjectAccessProjectPermissionsOptions
+	var workspaceAccess *tfe.TeamProjectAccessWorkspacePermissionsOptions
+	if access == tfe.TeamProjectAccessCustom {
+		projectAccess, workspaceAccess = customProjectTeamAccess(cmd, options)
+	}
+
+	if existing != nil {
+		// Update team access of the project
+		_, err = c.TeamProjectAccess.Update(ctx, existing.ID, tfe.TeamProjectAccessUpdateOptions{
+			Access:          &access,
+			ProjectAccess:   projectAccess,
+			WorkspaceAccess: workspaceAccess,
+		})
+	} else {
+		// Add team access for a project
+		_, err = c.TeamProjectAccess.Add(ctx, tfe.TeamProjectAccessAddOptions{
+			Access:          access,
+			ProjectAccess:   projectAccess,
+			WorkspaceAccess: workspaceAccess,
+			Team:            &tfe.Team{ID: teamID},
+			Project:         project,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("Team '" + options.teamName + "' has '" + options.access + "' access to project '" + options.projectName + "'")
+
+	return nil
+}
+
+func revokeProjectTeamAccess(_ *cobra.Command, options *accessOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	_, _, existing, err := findProjectTeamAccess(ctx, c, options)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("team '%s' has no access to project '%s'", options.teamName, options.projectName)
+	}
+
+	// Remove team access from a project
+	if err := c.TeamProjectAccess.Remove(ctx, existing.ID); err != nil {
+		return err
+	}
+	fmt.Println("Access of team '" + options.teamName + "' to project '" + options.projectName + "' revoked successfully!")
+
+	return nil
+}
+
+// validateProjectTeamAccess checks access level and custom permissions values
+func validateProjectTeamAccess(cmd *cobra.Command, options *accessOptions) error {
+	if err := utils.ValidateOneOf("access", options.access,
+		string(tfe.TeamProjectAccessRead), string(tfe.TeamProjectAccessWrite), string(tfe.TeamProjectAccessMaintain),
+		string(tfe.TeamProjectAccessAdmin), string(tfe.TeamProjectAccessCustom)); err != nil {
+		return err
+	}
+
+	if options.access != string(tfe.TeamProjectAccessCustom) {
+		for _, flag := range customAccessFlags {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("flag --%s is allowed only with 'custom' access level", flag)
+			}
+		}
+		return nil
+	}
+
+	checks := []struct {
+		flag    string
+		value   string
+		allowed []string
+	}{
+		{"settings", options.settings, []string{"read", "update", "delete"}},
+		{"teams", options.teams, []string{"none", "read", "manage"}},
+		{"variable-sets", options.variableSets, []string{"none", "read", "write"}},
+		{"runs", options.runs, []string{"read", "plan", "apply"}},
+		{"variables", options.variables, []string{"none", "read", "write"}},
+		{"state-versions", options.stateVersions, []string{"none", "read-outputs", "read", "write"}},
+		{"sentinel-mocks", options.sentinelMocks, []string{"none", "read"}},
+	}
+	for _, check := range checks {
+		if !cmd.Flags().Changed(check.flag) {
+			continue
+		}
+		if err := utils.ValidateOneOf(check.flag+" permission", check.value, check.allowed...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customProjectTeamAccess returns custom permissions set by flags, unset permissions are left to API defaults
+func customProjectTeamAccess(cmd *cobra.Command, options *accessOptions) (*tfe.TeamProjectAccessProjectPermissionsOptions,
+	*tfe.TeamProjectAccessWorkspacePermissionsOptions) {
+	flags := cmd.Flags()
+	projectAccess := &tfe.TeamProjectAccessProjectPermissionsOptions{}
+	workspaceAccess := &tfe.TeamProjectAccessWorkspacePermissionsOptions{}
+
+	if flags.Changed("settings") {
+		settings := tfe.ProjectSettingsPermissionType(options.settings)
+		projectAccess.Settings = &settings
+	}
+	if flags.Changed("teams") {
+		teams := tfe.ProjectTeamsPermissionType(options.teams)
+		projectAccess.Teams = &teams
+	}
+	if flags.Changed("variable-sets") {
+		variableSets := tfe.ProjectVariableSetsPermissionType(options.variableSets)
+		projectAccess.VariableSets = &variableSets
+	}
+	if flags.Changed("runs") {
+		runs := tfe.WorkspaceRunsPermissionType(options.runs)
+		workspaceAccess.Runs = &runs
+	}
+	if flags.Changed("variables") {
+		variables := tfe.WorkspaceVariablesPermissionType(options.variables)
+		workspaceAccess.Variables = &variables
+	}
+	if flags.Changed("state-versions") {
+		stateVersions := tfe.WorkspaceStateVersionsPermissionType(options.stateVersions)
+		workspaceAccess.StateVersions = &stateVersions
+	}
+	if flags.Changed("sentinel-mocks") {
+		sentinelMocks := tfe.WorkspaceSentinelMocksPermissionType(options.sentinelMocks)
+		workspaceAccess.SentinelMocks = &sentinelMocks
+	}
+
+	for flag, value := range map[string]**bool{
+		"create":    &workspaceAccess.Create,
+		"locking":   &workspaceAccess.Locking,
+		"move":      &workspaceAccess.Move,
+		"delete":    &workspaceAccess.Delete,
+		"run-tasks": &workspaceAccess.RunTasks,
+	} {
+		if flags.Changed(flag) {
+			enabled, _ := flags.GetBool(flag)
+			*value = tfe.Bool(enabled)
+		}
+	}
+
+	return projectAccess, workspaceAccess
+}
+
+// findProjectTeamAccess returns the project, team ID and existing team access to the project if any
+func findProjectTeamAccess(ctx context.Context, c *tfe.Client, options *accessOptions) (*tfe.Project, string, *tfe.TeamProjectAccess, error) {
+	project, err := readProject(ctx, c, options.TerraformOrganization, options.projectName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// List teams with provided name and filter team ID
+	teamList, err := c.Teams.List(ctx, options.TerraformOrganization, &tfe.TeamListOptions{Names: []string{options.teamName}})
+	if err != nil {
+		return nil, "", nil, err
+	}
+	teamID := utils.GetTeamID(teamList, options.teamName)
+	if teamID == "" {
+		return nil, "", nil, fmt.Errorf("team '%s' not found in organization '%s'", options.teamName, options.TerraformOrganization)
+	}
+
+	// List all the team accesses for a given project
+	accesses, err := listProjectAccesses(ctx, c, project.ID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	for _, access := range accesses {
+		if access.Team != nil && access.Team.ID == teamID {
+			return project, teamID, access, nil
+		}
+	}
+
+	return project, teamID, nil, nil
+}
+
+// listProjectAccesses returns all the team accesses of the project
+func listProjectAccesses(ctx context.Context, c *tfe.Client, projectID string) ([]*tfe.TeamProjectAccess, error) {
+	var accesses []*tfe.TeamProjectAccess
+
+	listOptions := tfe.TeamProjectAccessListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, ProjectID: projectID}
+	for {
+		accessList, err := c.TeamProjectAccess.List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		accesses = append(accesses, accessList.Items...)
+
+		if accessList.Pagination == nil || accessList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = accessList.NextPage
+	}
+
+	return accesses, nil
+}
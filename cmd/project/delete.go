@@ -0,0 +1,101 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// deleteOptions represents options for delete command
+type deleteOptions struct {
+	*projectOptions
+	projectName string
+	yes         bool
+}
+
+// NewProjectDeleteCmd returns new project delete command
+func NewProjectDeleteCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &deleteOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"del", "rm"},
+		Short:   "delete a terraform project by its name",
+		Long:    "delete a terraform project by its name. Only projects without workspaces can be deleted",
+		Example: "tfctl project delete [--project=...] [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteProject(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "name terraform project to delete")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func deleteProject(cmd *cobra.Command, options *deleteOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	project, err := readProject(ctx, c, options.TerraformOrganization, options.projectName)
+	if err != nil {
+		return err
+	}
+
+	// Check the project is empty, API refuses to delete projects with workspaces
+	workspaceList, err := c.Workspaces.List(ctx, options.TerraformOrganization, &tfe.WorkspaceListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 1},
+		ProjectID:   project.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if workspaceList.Pagination != nil && workspaceList.TotalCount > 0 {
+		return fmt.Errorf("project '%s' still contains %d workspace(s), move them to another project with 'tfctl ws move' first",
+			project.Name, workspaceList.TotalCount)
+	}
+
+	if !options.yes {
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Delete project '"+project.Name+"'?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Deletion of project '" + project.Name + "' canceled")
+			return nil
+		}
+	}
+
+	// Delete a project by its ID
+	if err := c.Projects.Delete(ctx, project.ID); err != nil {
+		return err
+	}
+	fmt.Println("Project '" + options.projectName + "' deleted successfully!")
+
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+// getOptions represents options for get command
+type getOptions struct {
+	*projectOptions
+	projectName string
+}
+
+// NewProjectGetCmd returns new project get command
+func NewProjectGetCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &getOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get",
+		Aliases: []string{"read"},
+		Short:   "read a project by its name",
+		Long:    "read a project by its name and organization name",
+		Example: "tfctl project get [--project=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getProject(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "terraform project name for getting info")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func getProject(_ *cobra.Command, options *getOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a project by its name and organization name
+	project, err := readProject(ctx, c, options.TerraformOrganization, options.projectName)
+	if err != nil {
+		return err
+	}
+
+	if options.Expand {
+		output.JsonOutput(project)
+	} else {
+		output.JsonPrettyOutput(project, "project")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// listOptions represents options for list command
+type listOptions struct {
+	*projectOptions
+	query string
+}
+
+// NewProjectListCmd returns new project list command
+func NewProjectListCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &listOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list all the projects within an organization",
+		Long:    "list all the projects within an organization",
+		Example: "tfctl project list [--query=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listProjects(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.query, "query", "q", "", "Optional: Show only projects with names like the query")
+
+	return cmd
+}
+
+func listProjects(_ *cobra.Command, options *listOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// List all the projects within an organization
+	listOptions := &tfe.ProjectListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, Query: options.query}
+	for {
+		projectList, err := c.Projects.List(ctx, options.TerraformOrganization, listOptions)
+		if err != nil {
+			return err
+		}
+
+		for _, project := range projectList.Items {
+			if options.Expand {
+				output.JsonOutput(project)
+			} else {
+				output.JsonPrettyOutput(project, "project")
+			}
+		}
+
+		if projectList.Pagination == nil || projectList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = projectList.NextPage
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// Package describes the project related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/projects
+
+type projectOptions struct {
+	*cmd.RootOptions
+}
+
+// NewProjectCmd create new project command
+func NewProjectCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &projectOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:     "project",
+		Aliases: []string{"prj"},
+		Short:   "Work with terraform projects",
+		Long:    "Work with terraform projects",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewProjectGetCmd(options))
+	cobraCmd.AddCommand(NewProjectListCmd(options))
+	cobraCmd.AddCommand(NewProjectSaveCmd(options))
+	cobraCmd.AddCommand(NewProjectDeleteCmd(options))
+	cobraCmd.AddCommand(NewProjectWorkspacesCmd(options))
+	cobraCmd.AddCommand(NewProjectVariableSetsCmd(options))
+	cobraCmd.AddCommand(NewProjectPolicySetsCmd(options))
+	cobraCmd.AddCommand(NewProjectAccessCmd(options))
+
+	return cobraCmd
+}
+
+// readProject returns project with given name within an organization
+func readProject(ctx context.Context, c *tfe.Client, organization, projectName string) (*tfe.Project, error) {
+	projectList, err := c.Projects.List(ctx, organization, &tfe.ProjectListOptions{Name: projectName})
+	if err != nil {
+		return nil, err
+	}
+
+	projectID := utils.GetProjectID(projectList, projectName)
+	if projectID == "" {
+		return nil, fmt.Errorf("project '%s' not found in organization '%s'", projectName, organization)
+	}
+
+	return c.Projects.Read(ctx, projectID)
+}
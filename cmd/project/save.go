@@ -0,0 +1,92 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// saveOptions represents options for save command
+type saveOptions struct {
+	*projectOptions
+	projectName string
+	description string
+}
+
+// NewProjectSaveCmd returns new project save command
+func NewProjectSaveCmd(projectOptions *projectOptions) *cobra.Command {
+	options := &saveOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "save",
+		Aliases: []string{"create"},
+		Short:   "save (create) given terraform project",
+		Long:    "save (create or update if already exists) given terraform project",
+		Example: "tfctl project save [--project=...] [--description=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return saveProject(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "name terraform project to create")
+	cmd.Flags().StringVarP(&options.description, "description", "d", "", "Optional: Description of the project")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func saveProject(cmd *cobra.Command, options *saveOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	projectList, err := c.Projects.List(ctx, options.TerraformOrganization, &tfe.ProjectListOptions{Name: options.projectName})
+	if err != nil {
+		return err
+	}
+
+	var project *tfe.Project
+	if projectID := utils.GetProjectID(projectList, options.projectName); projectID != "" {
+		// Update settings of an existing project, description is changed only if provided
+		updateOptions := tfe.ProjectUpdateOptions{Name: tfe.String(options.projectName)}
+		if cmd.Flags().Changed("description") {
+			updateOptions.Description = tfe.String(options.description)
+		}
+		project, err = c.Projects.Update(ctx, projectID, updateOptions)
+	} else {
+		// Create a new project
+		project, err = c.Projects.Create(ctx, options.TerraformOrganization, tfe.ProjectCreateOptions{
+			Name:        options.projectName,
+			Description: tfe.String(options.description),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	output.JsonPrettyOutput(project, "project")
+
+	return nil
+}
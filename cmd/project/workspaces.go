@@ -0,0 +1,206 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package project
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// resourcesOptions represents options for commands listing resources of a project
+type resourcesOptions struct {
+	*projectOptions
+	projectName string
+}
+
+// NewProjectWorkspacesCmd returns new project workspaces command
+func NewProjectWorkspacesCmd(projectOptions *projectOptions) *cobra.Command {
+	return newProjectResourcesCmd(projectOptions, "workspaces", []string{"ws"}, "list all the workspaces of a project", listProjectWorkspaces)
+}
+
+// NewProjectVariableSetsCmd returns new project variable sets command
+func NewProjectVariableSetsCmd(projectOptions *projectOptions) *cobra.Command {
+	return newProjectResourcesCmd(projectOptions, "variable-sets", []string{"varsets"},
+		"list all the variable sets applied to a project", listProjectVariableSets)
+}
+
+// NewProjectPolicySetsCmd returns new project policy sets command
+func NewProjectPolicySetsCmd(projectOptions *projectOptions) *cobra.Command {
+	return newProjectResourcesCmd(projectOptions, "policy-sets", []string{"policySets"},
+		"list all the policy sets enforced on a project (global and attached to the project)", listProjectPolicySets)
+}
+
+func newProjectResourcesCmd(projectOptions *projectOptions, use string, aliases []string, description string,
+	list func(context.Context, *resourcesOptions, *tfe.Project) error) *cobra.Command {
+	options := &resourcesOptions{
+		projectOptions: projectOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Aliases: aliases,
+		Short:   description,
+		Long:    description,
+		Example: "tfctl project " + use + " [--project=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			project, err := readProject(ctx, options.TClient, options.TerraformOrganization, options.projectName)
+			if err != nil {
+				return err
+			}
+			return list(ctx, options, project)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "terraform project name")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func listProjectWorkspaces(ctx context.Context, options *resourcesOptions, project *tfe.Project) error {
+	c := options.TClient
+
+	var workspaces []*tfe.Workspace
+	listOptions := &tfe.WorkspaceListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, ProjectID: project.ID}
+	for {
+		workspaceList, err := c.Workspaces.List(ctx, options.TerraformOrganization, listOptions)
+		if err != nil {
+			return err
+		}
+		workspaces = append(workspaces, workspaceList.Items...)
+		if workspaceList.Pagination == nil || workspaceList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = workspaceList.NextPage
+	}
+
+	if options.Expand {
+		output.JsonOutput(workspaces)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		rows = append(rows, []string{
+			workspace.Name,
+			workspace.ID,
+			workspace.TerraformVersion,
+			workspace.ExecutionMode,
+			strconv.Itoa(workspace.ResourceCount),
+		})
+	}
+	output.TableOutput([]string{"NAME", "ID", "TERRAFORM-VERSION", "EXECUTION-MODE", "RESOURCES"}, rows)
+
+	return nil
+}
+
+func listProjectVariableSets(ctx context.Context, options *resourcesOptions, project *tfe.Project) error {
+	c := options.TClient
+
+	var variableSets []*tfe.VariableSet
+	listOptions := &tfe.VariableSetListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		variableSetList, err := c.VariableSets.ListForProject(ctx, project.ID, listOptions)
+		if err != nil {
+			return err
+		}
+		variableSets = append(variableSets, variableSetList.Items...)
+		if variableSetList.Pagination == nil || variableSetList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = variableSetList.NextPage
+	}
+
+	if options.Expand {
+		output.JsonOutput(variableSets)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(variableSets))
+	for _, variableSet := range variableSets {
+		rows = append(rows, []string{
+			variableSet.Name,
+			variableSet.ID,
+			strconv.FormatBool(variableSet.Global),
+			strconv.FormatBool(variableSet.Priority),
+			variableSet.Description,
+		})
+	}
+	output.TableOutput([]string{"NAME", "ID", "GLOBAL", "PRIORITY", "DESCRIPTION"}, rows)
+
+	return nil
+}
+
+func listProjectPolicySets(ctx context.Context, options *resourcesOptions, project *tfe.Project) error {
+	c := options.TClient
+
+	var policySets []*tfe.PolicySet
+	listOptions := &tfe.PolicySetListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		Include:     []tfe.PolicySetIncludeOpt{tfe.PolicySetProjects},
+	}
+	for {
+		policySetList, err := c.PolicySets.List(ctx, options.TerraformOrganization, listOptions)
+		if err != nil {
+			return err
+		}
+		for _, policySet := range policySetList.Items {
+			if policySet.Global || containsProject(policySet.Projects, project.ID) {
+				policySets = append(policySets, policySet)
+			}
+		}
+		if policySetList.Pagination == nil || policySetList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = policySetList.NextPage
+	}
+
+	if options.Expand {
+		output.JsonOutput(policySets)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(policySets))
+	for _, policySet := range policySets {
+		scope := "project"
+		if policySet.Global {
+			scope = "global"
+		}
+		rows = append(rows, []string{policySet.Name, policySet.ID, string(policySet.Kind), scope})
+	}
+	output.TableOutput([]string{"NAME", "ID", "KIND", "SCOPE"}, rows)
+
+	return nil
+}
+
+// containsProject checks whether projects contain a project with given ID
+func containsProject(projects []*tfe.Project, projectID string) bool {
+	for _, p := range projects {
+		if p.ID == projectID {
+			return true
+		}
+	}
+
+	return false
+}
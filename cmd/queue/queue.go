@@ -0,0 +1,258 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/runstate"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// Package describes the run queue of an organization
+// that the Terraform Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/organizations#show-the-run-queue
+
+const (
+	stateActive  = "active"
+	stateWaiting = "waiting"
+)
+
+// queueOptions represents options for queue command
+type queueOptions struct {
+	*cmd.RootOptions
+	watch    bool
+	interval time.Duration
+}
+
+// queueItem represents a run in the queue of an organization
+type queueItem struct {
+	State       string        `json:"state"`
+	Workspace   string        `json:"workspace"`
+	RunID       string        `json:"run-id"`
+	Status      tfe.RunStatus `json:"status"`
+	AgentPool   string        `json:"agent-pool,omitempty"`
+	CreatedAt   time.Time     `json:"created-at"`
+	Wait        time.Duration `json:"-"`
+	WaitSeconds int64         `json:"wait-seconds"`
+}
+
+// queueReader reads the run queue, caching workspaces and agent pools of the runs between refreshes
+type queueReader struct {
+	client       *tfe.Client
+	organization string
+	workspaces   map[string]*tfe.Workspace
+	agentPools   map[string]string
+}
+
+// NewQueueCmd returns new queue command
+func NewQueueCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &queueOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Show run queue of terraform organization",
+		Long: "Show active and waiting runs of terraform organization with their workspaces, agent pools and wait time, " +
+			"the longest waiting first, and number of runs per agent pool",
+		Example: "tfctl queue [--watch] [--interval=10s] [-x]",
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return showQueue(cobraCmd, options)
+		},
+	}
+
+	cobraCmd.Flags().BoolVarP(&options.watch, "watch", "W", false, "Optional: Refresh the queue until interrupted")
+	cobraCmd.Flags().DurationVar(&options.interval, "interval", 10*time.Second, "Optional: Delay between two refreshes in watch mode")
+
+	return cobraCmd
+}
+
+func showQueue(_ *cobra.Command, options *queueOptions) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if options.interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", options.interval)
+	}
+
+	reader := &queueReader{
+		client:       options.TClient,
+		organization: options.TerraformOrganization,
+		workspaces:   map[string]*tfe.Workspace{},
+		agentPools:   map[string]string{},
+	}
+
+	for {
+		items, err := reader.read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		if options.Expand {
+			output.JsonOutput(items)
+		} else {
+			if options.watch {
+				// Clear the screen before the next refresh
+				fmt.Print("\033[H\033[2J")
+				fmt.Printf("Run queue of '%s' at %s, every %s\n\n", options.TerraformOrganization, time.Now().Format("15:04:05"), options.interval)
+			}
+			printQueue(items)
+		}
+
+		if !options.watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(options.interval):
+		}
+	}
+}
+
+// read returns the runs of the queue, active ones first and the longest waiting first among waiting ones
+func (r *queueReader) read(ctx context.Context) ([]*queueItem, error) {
+	now := time.Now()
+	var items []*queueItem
+
+	readOptions := tfe.ReadRunQueueOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		queue, err := r.client.Organizations.ReadRunQueue(ctx, r.organization, readOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range queue.Items {
+			item := &queueItem{RunID: run.ID, Status: run.Status, CreatedAt: run.CreatedAt, State: stateActive}
+			if runstate.IsWaiting(run.Status) {
+				item.State = stateWaiting
+				item.Wait = now.Sub(runstate.WaitingSince(run)).Round(time.Second)
+				item.WaitSeconds = int64(item.Wait.Seconds())
+			}
+			if run.Workspace != nil {
+				if item.Workspace, item.AgentPool, err = r.workspace(ctx, run.Workspace.ID); err != nil {
+					return nil, err
+				}
+			}
+			items = append(items, item)
+		}
+
+		if queue.Pagination == nil || queue.NextPage == 0 {
+			break
+		}
+		readOptions.PageNumber = queue.NextPage
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].State != items[j].State {
+			return items[i].State == stateActive
+		}
+		if items[i].Wait != items[j].Wait {
+			return items[i].Wait > items[j].Wait
+		}
+		return items[i].CreatedAt.Before(items[j].CreatedAt)
+	})
+
+	return items, nil
+}
+
+// workspace returns name and agent pool name (empty unless the workspace uses agents) of the workspace by its ID
+func (r *queueReader) workspace(ctx context.Context, workspaceID string) (string, string, error) {
+	workspace, ok := r.workspaces[workspaceID]
+	if !ok {
+		var err error
+		if workspace, err = r.client.Workspaces.ReadByID(ctx, workspaceID); err != nil {
+			return "", "", err
+		}
+		r.workspaces[workspaceID] = workspace
+	}
+
+	if workspace.ExecutionMode != "agent" || workspace.AgentPool == nil {
+		return workspace.Name, "", nil
+	}
+
+	agentPoolName, ok := r.agentPools[workspace.AgentPool.ID]
+	if !ok {
+		agentPool, err := r.client.AgentPools.Read(ctx, workspace.AgentPool.ID)
+		if err != nil {
+			return "", "", err
+		}
+		agentPoolName = agentPool.Name
+		r.agentPools[workspace.AgentPool.ID] = agentPoolName
+	}
+
+	return workspace.Name, agentPoolName, nil
+}
+
+// printQueue prints the runs of the queue followed by number of active and waiting runs per agent pool
+func printQueue(items []*queueItem) {
+	if len(items) == 0 {
+		fmt.Println("Run queue is empty")
+		return
+	}
+
+	type poolCount struct{ active, waiting int }
+	pools := map[string]*poolCount{}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		pool := item.AgentPool
+		if pool == "" {
+			pool = "-"
+		}
+		if pools[pool] == nil {
+			pools[pool] = &poolCount{}
+		}
+
+		wait := "-"
+		if item.State == stateWaiting {
+			wait = item.Wait.String()
+			pools[pool].waiting++
+		} else {
+			pools[pool].active++
+		}
+
+		rows = append(rows, []string{item.State, item.Workspace, item.RunID, string(item.Status), pool, item.CreatedAt.Local().Format("2006-01-02 15:04:05"), wait})
+	}
+	output.TableOutput([]string{"STATE", "WORKSPACE", "RUN", "STATUS", "AGENT POOL", "CREATED", "WAIT"}, rows)
+
+	names := make([]string, 0, len(pools))
+	for name := range pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println()
+	poolRows := make([][]string, 0, len(names))
+	for _, name := range names {
+		poolRows = append(poolRows, []string{name, fmt.Sprint(pools[name].active), fmt.Sprint(pools[name].waiting)})
+	}
+	output.TableOutput([]string{"AGENT POOL", "ACTIVE", "WAITING"}, poolRows)
+}
@@ -28,7 +28,7 @@ import (
 )
 
 type RootOptions struct {
-	terraformHostname     string
+	TerraformHostname     string
 	TerraformOrganization string
 	terraformToken        string
 	Expand                bool
@@ -50,7 +50,7 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 	cmd.SetErr(errWriter)
 
 	// Client flags
-	cmd.PersistentFlags().StringVar(&options.terraformHostname, "host", "app.terraform.io", "Terraform Enterprise (Cloud) host")
+	cmd.PersistentFlags().StringVar(&options.TerraformHostname, "host", "app.terraform.io", "Terraform Enterprise (Cloud) host")
 	cmd.PersistentFlags().StringVar(&options.TerraformOrganization, "org", os.Getenv("TF_ORG"), "Terraform Enterprise (Cloud) organization name")
 	cmd.PersistentFlags().StringVar(&options.terraformToken, "token", "", "Terraform Enterprise (Cloud) token")
 
@@ -62,50 +62,8 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 
 	// Initialize client
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		config := &tfe.Config{
-			Address: fmt.Sprintf("https://%s", options.terraformHostname),
-		}
-
-		// If variable 'TF_TOKEN' is empty, try obtain token from credentials file in user ${HOME} directory
-		if os.Getenv("TF_TOKEN") != "" {
-			config.Token = os.Getenv("TF_TOKEN")
-		} else {
-			home, err := os.UserHomeDir()
-			if err != nil {
-				return err
-			}
-
-			credentialsPath := home + "/.terraform.d/credentials.tfrc.json"
-
-			// #nosec G304 -- credentials file path is constructed from user home directory
-			jsonFile, err := os.Open(credentialsPath)
-			if err != nil {
-				return err
-			}
-			defer func() {
-				if closeErr := jsonFile.Close(); closeErr != nil {
-					// Log error but don't fail if file is already closed
-					_ = closeErr
-				}
-			}()
-
-			var token map[string]interface{}
-
-			byteValue, err := io.ReadAll(jsonFile)
-			if err != nil {
-				return err
-			}
-			if err := json.Unmarshal(byteValue, &token); err != nil {
-				return err
-			}
-
-			cred := token["credentials"].(map[string]interface{})
-			tftoken := cred[options.terraformHostname].(map[string]interface{})
-
-			config.Token = tftoken["token"].(string)
-		}
-
-		client, err := tfe.NewClient(config)
+		// If variable 'TF_TOKEN' is empty, token is obtained from credentials file in user ${HOME} directory
+		client, err := NewClient(options.TerraformHostname, os.Getenv("TF_TOKEN"))
 		if err != nil {
 			return err
 		}
@@ -117,3 +75,63 @@ func NewCmdRoot(outWriter, errWriter io.Writer) (*cobra.Command, *RootOptions) {
 
 	return cmd, options
 }
+
+// NewClient returns new Terraform Enterprise (Cloud) client for the given host.
+// If token is empty, try obtain it from credentials file in user ${HOME} directory
+func NewClient(hostname, token string) (*tfe.Client, error) {
+	config := &tfe.Config{
+		Address: fmt.Sprintf("https://%s", hostname),
+		Token:   token,
+	}
+
+	if config.Token == "" {
+		credentialsToken, err := readCredentialsToken(hostname)
+		if err != nil {
+			return nil, err
+		}
+		config.Token = credentialsToken
+	}
+
+	return tfe.NewClient(config)
+}
+
+// readCredentialsToken reads token for the given host from 'credentials.tfrc.json' created by 'terraform login'
+func readCredentialsToken(hostname string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	credentialsPath := home + "/.terraform.d/credentials.tfrc.json"
+
+	// #nosec G304 -- credentials file path is constructed from user home directory
+	jsonFile, err := os.Open(credentialsPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := jsonFile.Close(); closeErr != nil {
+			// Log error but don't fail if file is already closed
+			_ = closeErr
+		}
+	}()
+
+	var token map[string]interface{}
+
+	byteValue, err := io.ReadAll(jsonFile)
+	if err != nil {
+		return "", err
+	}
+	if err := json.Unmarshal(byteValue, &token); err != nil {
+		return "", err
+	}
+
+	cred, _ := token["credentials"].(map[string]interface{})
+	tftoken, _ := cred[hostname].(map[string]interface{})
+	hostToken, _ := tftoken["token"].(string)
+	if hostToken == "" {
+		return "", fmt.Errorf("no token for host '%s' found in %s", hostname, credentialsPath)
+	}
+
+	return hostToken, nil
+}
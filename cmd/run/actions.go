@@ -0,0 +1,192 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// actionOptions represents options for run lifecycle commands
+type actionOptions struct {
+	*runOptions
+	workspaceName string
+	comment       string
+	yes           bool
+	follow        bool
+}
+
+// runAction describes a run lifecycle action
+type runAction struct {
+	use         string
+	description string
+	done        string
+	// confirm requires interactive confirmation before the action
+	confirm bool
+	allowed func(*tfe.Run) bool
+	do      func(ctx context.Context, c *tfe.Client, runID string, comment *string) error
+}
+
+var (
+	applyAction = runAction{
+		use:         "apply",
+		description: "apply (confirm) a run which is waiting for confirmation",
+		done:        "confirmed",
+		confirm:     true,
+		allowed:     func(r *tfe.Run) bool { return r.Actions != nil && r.Actions.IsConfirmable },
+		do: func(ctx context.Context, c *tfe.Client, runID string, comment *string) error {
+			return c.Runs.Apply(ctx, runID, tfe.RunApplyOptions{Comment: comment})
+		},
+	}
+	discardAction = runAction{
+		use:         "discard",
+		description: "discard a run which is waiting for confirmation or policy override",
+		done:        "discarded",
+		allowed:     func(r *tfe.Run) bool { return r.Actions != nil && r.Actions.IsDiscardable },
+		do: func(ctx context.Context, c *tfe.Client, runID string, comment *string) error {
+			return c.Runs.Discard(ctx, runID, tfe.RunDiscardOptions{Comment: comment})
+		},
+	}
+	cancelAction = runAction{
+		use:         "cancel",
+		description: "interrupt a run which is currently planning or applying",
+		done:        "canceled",
+		allowed:     func(r *tfe.Run) bool { return r.Actions != nil && r.Actions.IsCancelable },
+		do: func(ctx context.Context, c *tfe.Client, runID string, comment *string) error {
+			return c.Runs.Cancel(ctx, runID, tfe.RunCancelOptions{Comment: comment})
+		},
+	}
+	forceCancelAction = runAction{
+		use:         "force-cancel",
+		description: "force cancel a run which was canceled but is still not finished, the workspace is unlocked immediately",
+		done:        "force canceled",
+		confirm:     true,
+		allowed:     func(r *tfe.Run) bool { return r.Actions != nil && r.Actions.IsForceCancelable },
+		do: func(ctx context.Context, c *tfe.Client, runID string, comment *string) error {
+			return c.Runs.ForceCancel(ctx, runID, tfe.RunForceCancelOptions{Comment: comment})
+		},
+	}
+)
+
+// NewRunApplyCmd returns new run apply command
+func NewRunApplyCmd(runOptions *runOptions) *cobra.Command {
+	cmd, options := newRunActionCmd(runOptions, applyAction)
+	cmd.Flags().BoolVarP(&options.follow, "follow", "f", false, "Optional: Follow the run status until it is finished")
+
+	return cmd
+}
+
+// NewRunDiscardCmd returns new run discard command
+func NewRunDiscardCmd(runOptions *runOptions) *cobra.Command {
+	cmd, _ := newRunActionCmd(runOptions, discardAction)
+	return cmd
+}
+
+// NewRunCancelCmd returns new run cancel command
+func NewRunCancelCmd(runOptions *runOptions) *cobra.Command {
+	cmd, _ := newRunActionCmd(runOptions, cancelAction)
+	return cmd
+}
+
+// NewRunForceCancelCmd returns new run force-cancel command
+func NewRunForceCancelCmd(runOptions *runOptions) *cobra.Command {
+	cmd, _ := newRunActionCmd(runOptions, forceCancelAction)
+	return cmd
+}
+
+func newRunActionCmd(runOptions *runOptions, action runAction) (*cobra.Command, *actionOptions) {
+	options := &actionOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     action.use + " [run-id]",
+		Short:   action.description,
+		Long:    action.description + ". The action is validated against the current run status",
+		Example: "tfctl run " + action.use + " run-CZcmD7eagjhyX0vN [--comment=...]\ntfctl run " + action.use + " --workspace=... [--comment=...]",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLifecycleAction(cmd, options, action, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the current run of the workspace instead of run ID")
+	cmd.Flags().StringVarP(&options.comment, "comment", "c", "", "Optional: Comment to add to the run")
+	if action.confirm {
+		cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	}
+
+	return cmd, options
+}
+
+func runLifecycleAction(cmd *cobra.Command, options *actionOptions, action runAction, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, true)
+	if err != nil {
+		return err
+	}
+
+	// Read a run with its plan to validate the action and show the plan summary
+	run, err := c.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{Include: []tfe.RunIncludeOpt{tfe.RunPlan}})
+	if err != nil {
+		return err
+	}
+
+	if !action.allowed(run) {
+		return fmt.Errorf("run '%s' can't be %s in status '%s'", run.ID, action.done, run.Status)
+	}
+	if action.use == forceCancelAction.use && time.Now().Before(run.ForceCancelAvailableAt) {
+		return fmt.Errorf("run '%s' can be force canceled only after %s", run.ID, run.ForceCancelAvailableAt.Local().Format("2006-01-02 15:04:05"))
+	}
+
+	if action.confirm && !options.yes {
+		fmt.Printf("Run '%s' (%s): %s\n", run.ID, run.Status, run.Message)
+		fmt.Printf("Plan: %s\n", runstate.PlanSummary(run.Plan))
+
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Do you want to "+action.use+" the run?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Run '" + run.ID + "' was not " + action.done)
+			return nil
+		}
+	}
+
+	var comment *string
+	if options.comment != "" {
+		comment = tfe.String(options.comment)
+	}
+	if err := action.do(ctx, c, run.ID, comment); err != nil {
+		return err
+	}
+	fmt.Println("Run '" + run.ID + "' " + action.done + " successfully!")
+
+	if options.follow {
+		return followRun(ctx, c, run.ID)
+	}
+
+	return nil
+}
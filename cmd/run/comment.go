@@ -0,0 +1,77 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// commentOptions represents options for comment command
+type commentOptions struct {
+	*runOptions
+	workspaceName string
+}
+
+// NewRunCommentCmd returns new run comment command
+func NewRunCommentCmd(runOptions *runOptions) *cobra.Command {
+	options := &commentOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "comment [run-id] <text>",
+		Short:   "add a comment to a run",
+		Long:    "add a comment to a run given by its ID or to the latest run of a workspace",
+		Example: "tfctl run comment run-CZcmD7eagjhyX0vN \"Approved in CHANGE-1234\"\ntfctl run comment --workspace=... \"...\"",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commentRun(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Comment the latest run of the workspace instead of run ID")
+
+	return cmd
+}
+
+func commentRun(_ *cobra.Command, options *commentOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// The comment text is always the last argument
+	text := strings.TrimSpace(args[len(args)-1])
+	if text == "" {
+		return fmt.Errorf("comment text must not be empty")
+	}
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args[:len(args)-1], options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	// Create a new comment of the run
+	if _, err := c.Comments.Create(ctx, runID, tfe.CommentCreateOptions{Body: text}); err != nil {
+		return err
+	}
+	fmt.Println("Comment added to run '" + runID + "' successfully!")
+
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ealebed/tfctl/pkg/cost"
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// costOptions represents options for cost command
+type costOptions struct {
+	*runOptions
+	workspaceName string
+}
+
+// runCost represents cost estimate of a run with per-resource breakdown
+type runCost struct {
+	RunID               string              `json:"run-id"`
+	Status              string              `json:"status"`
+	PriorMonthlyCost    string              `json:"prior-monthly-cost"`
+	ProposedMonthlyCost string              `json:"proposed-monthly-cost"`
+	DeltaMonthlyCost    string              `json:"delta-monthly-cost"`
+	Resources           []cost.ResourceCost `json:"resources"`
+	UnmatchedResources  []cost.ResourceCost `json:"unmatched-resources"`
+}
+
+// NewRunCostCmd returns new run cost command
+func NewRunCostCmd(runOptions *runOptions) *cobra.Command {
+	options := &costOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cost [run-id]",
+		Short:   "show cost estimation results of a run",
+		Long:    "show prior, proposed and delta monthly cost of a run with per-resource breakdown",
+		Example: "tfctl run cost run-CZcmD7eagjhyX0vN\ntfctl run cost --workspace=...",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showRunCost(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+
+	return cmd
+}
+
+func showRunCost(_ *cobra.Command, options *costOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	// Read a run with its cost estimate
+	run, err := c.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{Include: []tfe.RunIncludeOpt{tfe.RunCostEstimate}})
+	if err != nil {
+		return err
+	}
+	if run.CostEstimate == nil {
+		return fmt.Errorf("run '%s' has no cost estimate, cost estimation is disabled for the organization or the run has not been planned yet", run.ID)
+	}
+
+	estimate, err := c.CostEstimates.Read(ctx, run.CostEstimate.ID)
+	if err != nil {
+		return err
+	}
+	if estimate.Status != tfe.CostEstimateFinished {
+		if estimate.ErrorMessage != "" {
+			return fmt.Errorf("cost estimate of run '%s' is %s: %s", run.ID, estimate.Status, estimate.ErrorMessage)
+		}
+		return fmt.Errorf("cost estimate of run '%s' is %s", run.ID, estimate.Status)
+	}
+
+	breakdown, err := readCostBreakdown(ctx, c, estimate.ID)
+	if err != nil {
+		return err
+	}
+
+	result := &runCost{
+		RunID:               run.ID,
+		Status:              string(estimate.Status),
+		PriorMonthlyCost:    estimate.PriorMonthlyCost,
+		ProposedMonthlyCost: estimate.ProposedMonthlyCost,
+		DeltaMonthlyCost:    estimate.DeltaMonthlyCost,
+		Resources:           breakdown.Resources.Matched,
+		UnmatchedResources:  breakdown.Resources.Unmatched,
+	}
+
+	if options.Expand {
+		output.JsonOutput(result)
+		return nil
+	}
+
+	return printRunCost(result)
+}
+
+// readCostBreakdown reads per-resource breakdown from the cost estimate log
+func readCostBreakdown(ctx context.Context, c *tfe.Client, costEstimateID string) (*cost.Breakdown, error) {
+	logs, err := c.CostEstimates.Logs(ctx, costEstimateID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	return cost.ParseBreakdown(data)
+}
+
+func printRunCost(result *runCost) error {
+	prior, err := cost.ParseAmount(result.PriorMonthlyCost)
+	if err != nil {
+		return err
+	}
+	proposed, err := cost.ParseAmount(result.ProposedMonthlyCost)
+	if err != nil {
+		return err
+	}
+	delta, err := cost.ParseAmount(result.DeltaMonthlyCost)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Run:      %s\n", result.RunID)
+	fmt.Printf("Prior:    %s/mo\n", cost.FormatAmount(prior))
+	fmt.Printf("Proposed: %s/mo\n", cost.FormatAmount(proposed))
+	fmt.Printf("Delta:    %s/mo\n", cost.FormatDelta(delta))
+
+	if len(result.Resources) > 0 {
+		fmt.Println()
+		rows := make([][]string, 0, len(result.Resources))
+		for _, resource := range result.Resources {
+			row := []string{resource.Address, resource.Type}
+			for i, amount := range []string{resource.PriorMonthlyCost, resource.ProposedMonthlyCost, resource.DeltaMonthlyCost} {
+				value, err := cost.ParseAmount(amount)
+				if err != nil {
+					return err
+				}
+				if i == 2 {
+					row = append(row, cost.FormatDelta(value))
+				} else {
+					row = append(row, cost.FormatAmount(value))
+				}
+			}
+			rows = append(rows, row)
+		}
+		output.TableOutput([]string{"RESOURCE", "TYPE", "PRIOR", "PROPOSED", "DELTA"}, rows)
+	}
+
+	if len(result.UnmatchedResources) > 0 {
+		fmt.Printf("\n%d resource(s) without price information:\n", len(result.UnmatchedResources))
+		for _, resource := range result.UnmatchedResources {
+			fmt.Printf("  %s\n", resource.Address)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/cost"
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// costReportOptions represents options for cost-report command
+type costReportOptions struct {
+	*runOptions
+	since  string
+	status string
+}
+
+// workspaceCost represents aggregated cost changes of a workspace
+type workspaceCost struct {
+	Workspace    string  `json:"workspace"`
+	Runs         int     `json:"runs"`
+	Delta        float64 `json:"delta-monthly-cost"`
+	LastProposed float64 `json:"last-proposed-monthly-cost"`
+}
+
+// NewRunCostReportCmd returns new run cost-report command
+func NewRunCostReportCmd(runOptions *runOptions) *cobra.Command {
+	options := &costReportOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "cost-report",
+		Short: "report cost changes of recent runs within an organization",
+		Long: "aggregate monthly cost deltas of recent runs with finished cost estimates per workspace. " +
+			"Only applied runs are included by default, plan-only, discarded, errored and canceled runs are never counted",
+		Example: "tfctl run cost-report [--since=7d] [--status=applied]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reportRunCosts(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.since, "since", "7d", "Optional: Include runs created after the time (e.g. '24h', '7d', '2006-01-02')")
+	cmd.Flags().StringVar(&options.status, "status", string(tfe.RunApplied),
+		"Optional: Comma separated list of run statuses (e.g. 'applied,planned' to include runs waiting for confirmation)")
+
+	return cmd
+}
+
+func reportRunCosts(_ *cobra.Command, options *costReportOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	since, err := utils.ParseSince(options.since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fetch, err := runPageFetcher(ctx, c, &listOptions{runOptions: options.runOptions, status: options.status}, tfe.RunCostEstimate)
+	if err != nil {
+		return err
+	}
+	runs, err := collectRuns(fetch, since, "", 0)
+	if err != nil {
+		return err
+	}
+
+	report, err := aggregateRunCosts(runs)
+	if err != nil {
+		return err
+	}
+
+	if options.Expand {
+		output.JsonOutput(report)
+		return nil
+	}
+
+	var total float64
+	rows := make([][]string, 0, len(report)+1)
+	for _, item := range report {
+		total += item.Delta
+		rows = append(rows, []string{item.Workspace, strconv.Itoa(item.Runs), cost.FormatDelta(item.Delta), cost.FormatAmount(item.LastProposed)})
+	}
+	rows = append(rows, []string{"TOTAL", "", cost.FormatDelta(total), ""})
+	output.TableOutput([]string{"WORKSPACE", "RUNS", "DELTA", "LAST-PROPOSED"}, rows)
+
+	return nil
+}
+
+// aggregateRunCosts sums cost deltas of runs (the newest first) per workspace, the most expensive changes first.
+// Changes which are never applied (plan-only, discarded, errored or canceled runs) are skipped
+func aggregateRunCosts(runs []*tfe.Run) ([]*workspaceCost, error) {
+	byWorkspace := map[string]*workspaceCost{}
+	for _, run := range runs {
+		switch {
+		case run.PlanOnly,
+			run.Status == tfe.RunDiscarded,
+			run.Status == tfe.RunErrored,
+			run.Status == tfe.RunCanceled:
+			continue
+		}
+		if run.Workspace == nil || run.CostEstimate == nil || run.CostEstimate.Status != tfe.CostEstimateFinished {
+			continue
+		}
+
+		delta, err := cost.ParseAmount(run.CostEstimate.DeltaMonthlyCost)
+		if err != nil {
+			return nil, err
+		}
+
+		item, ok := byWorkspace[run.Workspace.Name]
+		if !ok {
+			proposed, err := cost.ParseAmount(run.CostEstimate.ProposedMonthlyCost)
+			if err != nil {
+				return nil, err
+			}
+			item = &workspaceCost{Workspace: run.Workspace.Name, LastProposed: proposed}
+			byWorkspace[run.Workspace.Name] = item
+		}
+		item.Runs++
+		item.Delta += delta
+	}
+
+	report := make([]*workspaceCost, 0, len(byWorkspace))
+	for _, item := range byWorkspace {
+		report = append(report, item)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Delta != report[j].Delta {
+			return report[i].Delta > report[j].Delta
+		}
+		return report[i].Workspace < report[j].Workspace
+	})
+
+	return report, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/runstate"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// eventsOptions represents options for events command
+type eventsOptions struct {
+	*runOptions
+	workspaceName string
+}
+
+// NewRunEventsCmd returns new run events command
+func NewRunEventsCmd(runOptions *runOptions) *cobra.Command {
+	options := &eventsOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "events [run-id]",
+		Aliases: []string{"timeline"},
+		Short:   "show the timeline of a run with actors and comments",
+		Long: "show the timeline of a run (queued, planned, policy checked, confirmed, applied, commented) with users " +
+			"who performed the actions and their comments, or status changes of the run if its events are not available",
+		Example: "tfctl run events run-CZcmD7eagjhyX0vN\ntfctl run events --workspace=...",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showRunEvents(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+
+	return cmd
+}
+
+func showRunEvents(_ *cobra.Command, options *eventsOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	// List events of the run with users and comments
+	events, err := c.RunEvents.List(ctx, runID, &tfe.RunEventListOptions{Include: []tfe.RunEventIncludeOpt{tfe.RunEventActor, tfe.RunEventComment}})
+	if err != nil {
+		return err
+	}
+
+	if len(events.Items) > 0 {
+		if options.Expand {
+			output.JsonOutput(events.Items)
+			return nil
+		}
+		printRunEvents(events.Items)
+		return nil
+	}
+
+	// Fall back to status timestamps if the run events are not available (e.g. older Terraform Enterprise)
+	run, err := c.Runs.Read(ctx, runID)
+	if err != nil {
+		return err
+	}
+	timeline := runstate.Timeline(run)
+	if options.Expand {
+		output.JsonOutput(timeline)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(timeline))
+	for _, change := range timeline {
+		rows = append(rows, []string{change.At.Local().Format("2006-01-02 15:04:05"), string(change.Status)})
+	}
+	output.TableOutput([]string{"TIME", "STATUS"}, rows)
+
+	return nil
+}
+
+func printRunEvents(events []*tfe.RunEvent) {
+	rows := make([][]string, 0, len(events))
+	for _, event := range events {
+		actor := ""
+		if event.Actor != nil {
+			actor = event.Actor.Username
+		}
+		comment := ""
+		if event.Comment != nil {
+			comment = strings.Join(strings.Fields(event.Comment.Body), " ")
+		}
+
+		rows = append(rows, []string{
+			event.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			event.Action,
+			actor,
+			event.Description,
+			comment,
+		})
+	}
+
+	output.TableOutput([]string{"TIME", "ACTION", "ACTOR", "DESCRIPTION", "COMMENT"}, rows)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// listOptions represents options for list command
+type listOptions struct {
+	*runOptions
+	workspaceName string
+	status        string
+	since         string
+	source        string
+	operation     string
+	user          string
+	limit         int
+}
+
+// runPage is a single page of runs with the number of the next page, 0 if it is the last one
+type runPage struct {
+	runs     []*tfe.Run
+	nextPage int
+}
+
+// NewRunListCmd returns new run list command
+func NewRunListCmd(runOptions *runOptions) *cobra.Command {
+	options := &listOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list runs of a workspace or all the runs within an organization",
+		Long:    "list runs of a workspace or all the runs within an organization, the newest first",
+		Example: "tfctl run list [--workspace=...] [--status=planned,errored] [--since=24h] [--source=tfe-api] [--operation=plan_only] [--user=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listRuns(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "Optional: List runs of the workspace only")
+	cmd.Flags().StringVar(&options.status, "status", "", "Optional: Comma separated list of run statuses")
+	cmd.Flags().StringVar(&options.since, "since", "", "Optional: Show runs created after the time (e.g. '24h', '7d', '2006-01-02')")
+	cmd.Flags().StringVar(&options.source, "source", "", "Optional: Comma separated list of run sources (tfe-ui, tfe-api, tfe-configuration-version, ...)")
+	cmd.Flags().StringVar(&options.operation, "operation", "", "Optional: Comma separated list of run operations (plan_only, plan_and_apply, destroy, ...)")
+	cmd.Flags().StringVar(&options.user, "user", "", "Optional: Show runs created by the user (username)")
+	cmd.Flags().IntVar(&options.limit, "limit", 0, "Optional: Maximum number of runs to show (0 means all)")
+
+	return cmd
+}
+
+func listRuns(_ *cobra.Command, options *listOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	var since time.Time
+	if options.since != "" {
+		var err error
+		if since, err = utils.ParseSince(options.since, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	fetch, err := runPageFetcher(ctx, c, options)
+	if err != nil {
+		return err
+	}
+
+	runs, err := collectRuns(fetch, since, options.user, options.limit)
+	if err != nil {
+		return err
+	}
+
+	if options.Expand {
+		output.JsonOutput(runs)
+		return nil
+	}
+	printRunsTable(runs, options.workspaceName == "")
+
+	return nil
+}
+
+// collectRuns reads runs page by page until the first run created before since (if set),
+// keeping runs created by the user (if set) and stopping after limit runs (if positive)
+func collectRuns(fetch func(page int) (*runPage, error), since time.Time, user string, limit int) ([]*tfe.Run, error) {
+	var runs []*tfe.Run
+	for page := 1; page != 0; {
+		result, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		page = result.nextPage
+
+		for _, run := range result.runs {
+			// Runs are sorted from the newest to the oldest one
+			if !since.IsZero() && run.CreatedAt.Before(since) {
+				page = 0
+				break
+			}
+			if user != "" && (run.CreatedBy == nil || run.CreatedBy.Username != user) {
+				continue
+			}
+			runs = append(runs, run)
+			if limit > 0 && len(runs) >= limit {
+				page = 0
+				break
+			}
+		}
+	}
+
+	return runs, nil
+}
+
+// runPageFetcher returns function reading runs page by page for a workspace or the whole organization
+func runPageFetcher(
+	ctx context.Context, c *tfe.Client, options *listOptions, extra ...tfe.RunIncludeOpt,
+) (func(page int) (*runPage, error), error) {
+	include := append([]tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunCreatedBy}, extra...)
+
+	if options.workspaceName == "" {
+		return func(page int) (*runPage, error) {
+			// List all the runs within an organization
+			runList, err := c.Runs.ListForOrganization(ctx, options.TerraformOrganization, &tfe.RunListForOrganizationOptions{
+				ListOptions: tfe.ListOptions{PageNumber: page, PageSize: 100},
+				Status:      options.status,
+				Source:      options.source,
+				Operation:   options.operation,
+				Include:     append(include, tfe.RunWorkspace),
+			})
+			if err != nil {
+				return nil, err
+			}
+			result := &runPage{runs: runList.Items}
+			if runList.PaginationNextPrev != nil {
+				result.nextPage = runList.NextPage
+			}
+			return result, nil
+		}, nil
+	}
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(page int) (*runPage, error) {
+		// List all the runs of the workspace
+		runList, err := c.Runs.List(ctx, workspace.ID, &tfe.RunListOptions{
+			ListOptions: tfe.ListOptions{PageNumber: page, PageSize: 100},
+			Status:      options.status,
+			Source:      options.source,
+			Operation:   options.operation,
+			Include:     include,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result := &runPage{runs: runList.Items}
+		if runList.Pagination != nil {
+			result.nextPage = runList.NextPage
+		}
+		return result, nil
+	}, nil
+}
+
+func printRunsTable(runs []*tfe.Run, withWorkspace bool) {
+	now := time.Now()
+
+	headers := []string{"RUN", "STATUS", "CREATED", "DURATION", "CHANGES", "SOURCE", "TRIGGER", "USER"}
+	if withWorkspace {
+		headers = append([]string{"WORKSPACE"}, headers...)
+	}
+
+	rows := make([][]string, 0, len(runs))
+	for _, run := range runs {
+		user := ""
+		if run.CreatedBy != nil {
+			user = run.CreatedBy.Username
+		}
+		row := []string{
+			run.ID,
+			string(run.Status),
+			run.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			runstate.Duration(run, now).String(),
+			resourceChanges(run.Plan),
+			string(run.Source),
+			run.TriggerReason,
+			user,
+		}
+		if withWorkspace {
+			workspace := ""
+			if run.Workspace != nil {
+				workspace = run.Workspace.Name
+			}
+			row = append([]string{workspace}, row...)
+		}
+		rows = append(rows, row)
+	}
+	output.TableOutput(headers, rows)
+}
+
+// resourceChanges returns short summary of resource changes proposed by the plan
+func resourceChanges(plan *tfe.Plan) string {
+	if plan == nil || plan.Status == tfe.PlanPending || plan.Status == tfe.PlanUnreachable {
+		return "-"
+	}
+
+	return fmt.Sprintf("+%d ~%d -%d", plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)
+}
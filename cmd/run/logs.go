@@ -0,0 +1,225 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/runlog"
+	"github.com/ealebed/tfctl/pkg/runstate"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// logsOptions represents options for logs command
+type logsOptions struct {
+	*runOptions
+	workspaceName string
+	follow        bool
+	noColor       bool
+	jsonPath      string
+	timeout       time.Duration
+}
+
+// NewRunLogsCmd returns new run logs command
+func NewRunLogsCmd(runOptions *runOptions) *cobra.Command {
+	options := &logsOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "logs [run-id]",
+		Aliases: []string{"log"},
+		Short:   "print plan and apply logs of a run",
+		Long: "print plan and apply logs of a run (or the latest run of a workspace). With --follow waits for " +
+			"the phases which are not started yet and streams the logs until the run is finished",
+		Example: "tfctl run logs run-CZcmD7eagjhyX0vN [--follow] [--no-color] [--json-out=plan.jsonl]\ntfctl run logs --workspace=... --follow [--timeout=1h]",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printRunLogs(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+	cmd.Flags().BoolVarP(&options.follow, "follow", "f", false, "Optional: Wait for the phases not started yet and stream logs until the run is finished")
+	cmd.Flags().BoolVar(&options.noColor, "no-color", false, "Optional: Strip ANSI colors from the logs")
+	cmd.Flags().StringVar(&options.jsonPath, "json-out", "", "Optional: File to write structured JSON log lines to")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", time.Hour, "Optional: Maximum time to wait for the phases and stream their logs")
+
+	return cmd
+}
+
+func printRunLogs(cmd *cobra.Command, options *logsOptions, args []string) error {
+	c := options.TClient
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+	defer cancel()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	copyOptions := runlog.Options{StripColors: options.noColor}
+	if options.jsonPath != "" {
+		// #nosec G304 -- path to the output file is provided by the user
+		jsonFile, err := os.Create(options.jsonPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = jsonFile.Close()
+		}()
+		copyOptions.JSON = jsonFile
+	}
+
+	if err := copyRunLogs(ctx, cmd, options, runID, copyOptions); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("logs of run '%s' weren't finished within %s", runID, options.timeout)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// copyRunLogs prints plan and apply logs of the run, waiting for the phases with follow option
+func copyRunLogs(ctx context.Context, cmd *cobra.Command, options *logsOptions, runID string, copyOptions runlog.Options) error {
+	c := options.TClient
+
+	run, err := c.Runs.Read(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	// Plan logs
+	plan, err := waitForPlan(ctx, c, run.Plan.ID, options.follow)
+	if err != nil {
+		return err
+	}
+	if planStarted(plan.Status) {
+		if err := copyLogs(ctx, cmd.OutOrStdout(), copyOptions, plan.ID, c.Plans.Logs); err != nil {
+			return err
+		}
+	}
+
+	// Apply logs
+	if options.follow {
+		if run, err = waitForApply(ctx, cmd.ErrOrStderr(), c, run.ID); err != nil {
+			return err
+		}
+	} else if run, err = c.Runs.Read(ctx, run.ID); err != nil {
+		return err
+	}
+	if run.Apply == nil {
+		return nil
+	}
+	apply, err := c.Applies.Read(ctx, run.Apply.ID)
+	if err != nil {
+		return err
+	}
+	if applyStarted(apply.Status) {
+		return copyLogs(ctx, cmd.OutOrStdout(), copyOptions, apply.ID, c.Applies.Logs)
+	}
+
+	return nil
+}
+
+// copyLogs streams logs of a plan or an apply until the phase is finished
+func copyLogs(ctx context.Context, out io.Writer, options runlog.Options, id string,
+	logs func(context.Context, string) (io.Reader, error)) error {
+	reader, err := logs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return runlog.Copy(out, reader, options)
+}
+
+// planStarted returns true if a plan with given status has (or is about to have) logs
+func planStarted(status tfe.PlanStatus) bool {
+	switch status {
+	case "", tfe.PlanPending, tfe.PlanUnreachable:
+		return false
+	default:
+		return true
+	}
+}
+
+// applyStarted returns true if an apply with given status has (or is about to have) logs
+func applyStarted(status tfe.ApplyStatus) bool {
+	switch status {
+	case "", tfe.ApplyPending, tfe.ApplyUnreachable:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitForPlan reads the plan and, if wait is set, polls it until it leaves pending status
+func waitForPlan(ctx context.Context, c *tfe.Client, planID string, wait bool) (*tfe.Plan, error) {
+	for {
+		plan, err := c.Plans.Read(ctx, planID)
+		if err != nil {
+			return nil, err
+		}
+		if !wait || plan.Status != tfe.PlanPending {
+			return plan, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return plan, ctx.Err()
+		case <-time.After(runstate.DefaultInterval):
+		}
+	}
+}
+
+// waitForApply polls the run until its apply is started or the run is finished without it
+func waitForApply(ctx context.Context, out io.Writer, c *tfe.Client, runID string) (*tfe.Run, error) {
+	notified := false
+	for {
+		run, err := c.Runs.Read(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch run.Status {
+		case tfe.RunApplyQueued, tfe.RunApplying, tfe.RunApplied:
+			return run, nil
+		}
+		if runstate.IsFinal(run.Status) {
+			return run, nil
+		}
+
+		if runstate.NeedsAction(run) && !notified {
+			fmt.Fprintln(out, "Run '"+run.ID+"' is waiting for confirmation or policy override...")
+			notified = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case <-time.After(runstate.DefaultInterval):
+		}
+	}
+}
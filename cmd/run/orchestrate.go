@@ -0,0 +1,406 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/graph"
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+const (
+	onFailureStop     = "stop"
+	onFailureContinue = "continue"
+
+	// runSourceRunTrigger is the source of runs queued by run triggers, go-tfe doesn't define it
+	runSourceRunTrigger tfe.RunSource = "tfe-run-trigger"
+)
+
+// orchestrateOptions represents options for orchestrate command
+type orchestrateOptions struct {
+	*runOptions
+	selector     string
+	dependencies string
+	message      string
+	onFailure    string
+	timeout      time.Duration
+	parallelism  int
+	remoteState  bool
+	autoApply    bool
+	dryRun       bool
+	yes          bool
+}
+
+// orchestration represents state of the ordered runs in the selected workspaces
+type orchestration struct {
+	options    *orchestrateOptions
+	graph      *graph.Graph
+	workspaces map[string]*tfe.Workspace
+	startedAt  time.Time
+
+	mu      sync.Mutex
+	results map[string]*orchestrationResult
+	failed  bool
+}
+
+// orchestrationResult represents the outcome of the run in a workspace
+type orchestrationResult struct {
+	Workspace string        `json:"workspace"`
+	Level     int           `json:"level"`
+	Result    string        `json:"result"`
+	RunID     string        `json:"run-id,omitempty"`
+	Message   string        `json:"message,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// NewRunOrchestrateCmd returns new run orchestrate command
+func NewRunOrchestrateCmd(runOptions *runOptions) *cobra.Command {
+	options := &orchestrateOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "orchestrate",
+		Short: "queue and apply runs in selected workspaces in the order of their dependencies",
+		Long: "sort selected workspaces topologically by run triggers (and optionally remote state sharing) or by a dependency file, " +
+			"then queue runs level by level with parallelism within a level, wait for each apply and stop or continue on failure",
+		Example: "tfctl run orchestrate --selector=... [--dependencies=deps.json] [--parallelism=...] [--on-failure=stop|continue] [--auto-apply] [--dry-run]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return orchestrateRuns(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cmd.Flags().StringVarP(&options.dependencies, "dependencies", "d", "",
+		"Optional: JSON file mapping workspace names to lists of workspaces they depend on, used instead of run triggers")
+	cmd.Flags().StringVarP(&options.message, "message", "m", "Queued by tfctl run orchestrate", "Optional: Message describing the runs")
+	cmd.Flags().StringVar(&options.onFailure, "on-failure", onFailureStop,
+		"Optional: Stop after the level with a failed workspace (stop) or continue with workspaces not depending on it (continue)")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", time.Hour, "Optional: Maximum time to wait for the run of a workspace")
+	cmd.Flags().IntVarP(&options.parallelism, "parallelism", "p", worker.DefaultWorkers, "Optional: Number of workspaces of a level run concurrently")
+	cmd.Flags().BoolVar(&options.remoteState, "remote-state", false, "Optional: Order workspaces by remote state sharing in addition to run triggers")
+	cmd.Flags().BoolVar(&options.autoApply, "auto-apply", false,
+		"Optional: Apply runs automatically when plan succeeds, otherwise wait until they are confirmed")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "Optional: Only print the order of workspaces without queueing runs")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip confirmation prompt")
+	cmd.MarkFlagsMutuallyExclusive("dependencies", "remote-state")
+	if err := cmd.MarkFlagRequired("selector"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func orchestrateRuns(cmd *cobra.Command, options *orchestrateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := utils.ValidateOneOf("on-failure", options.onFailure, onFailureStop, onFailureContinue); err != nil {
+		return err
+	}
+
+	sel, err := selector.Parse(options.selector)
+	if err != nil {
+		return err
+	}
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		fmt.Println("No workspaces match the selector")
+		return nil
+	}
+
+	o := &orchestration{
+		options:    options,
+		workspaces: map[string]*tfe.Workspace{},
+		results:    map[string]*orchestrationResult{},
+	}
+	names := make([]string, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		o.workspaces[workspace.Name] = workspace
+		names = append(names, workspace.Name)
+	}
+
+	// The selected workspaces keep the order of the full graph, e.g. when they depend on each other
+	// through workspaces which are not selected
+	if o.graph, err = dependencyGraph(ctx, options); err != nil {
+		return err
+	}
+	o.graph = o.graph.Subgraph(names)
+	levels, err := o.graph.Levels()
+	if err != nil {
+		return err
+	}
+
+	for i, level := range levels {
+		fmt.Printf("Level %d: %s\n", i+1, strings.Join(level, ", "))
+	}
+	if options.dryRun {
+		return nil
+	}
+	if !options.yes {
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), fmt.Sprintf("Queue runs in %d workspace(s)?", len(workspaces)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Orchestration canceled")
+			return nil
+		}
+	}
+
+	return o.run(ctx, levels)
+}
+
+// dependencyGraph returns dependencies declared in the dependency file or built from the API for all the workspaces of the organization
+func dependencyGraph(ctx context.Context, options *orchestrateOptions) (*graph.Graph, error) {
+	if options.dependencies == "" {
+		workspaces, err := selector.Resolve(ctx, options.TClient, options.TerraformOrganization, &selector.Selector{})
+		if err != nil {
+			return nil, err
+		}
+		return graph.Build(ctx, options.TClient, workspaces, graph.BuildOptions{RemoteState: options.remoteState, Workers: options.parallelism})
+	}
+
+	// #nosec G304 -- path to the dependency file is provided by the user
+	data, err := os.ReadFile(options.dependencies)
+	if err != nil {
+		return nil, err
+	}
+	return graph.ParseDependencies(data)
+}
+
+// run executes the levels one by one and prints the summary
+func (o *orchestration) run(ctx context.Context, levels [][]string) error {
+	o.startedAt = time.Now()
+
+	for i, level := range levels {
+		var queued []string
+		for _, name := range level {
+			if reason := o.skipReason(name); reason != "" {
+				o.setResult(&orchestrationResult{Workspace: name, Level: i + 1, Result: "skipped", Message: reason})
+				continue
+			}
+			queued = append(queued, name)
+		}
+
+		worker.Run(ctx, queued, worker.Options{Workers: o.options.parallelism}, func(ctx context.Context, name string) error {
+			started := time.Now()
+			result := o.applyWorkspace(ctx, o.workspaces[name])
+			result.Level = i + 1
+			result.Duration = time.Since(started).Round(time.Second)
+			o.setResult(result)
+			return nil
+		})
+	}
+
+	return o.summary(levels)
+}
+
+// skipReason returns why the workspace is not run: failure in a previous level or in its dependency
+func (o *orchestration) skipReason(name string) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.failed && o.options.onFailure == onFailureStop {
+		return "stopped after failure in a previous level"
+	}
+	for _, dependency := range o.graph.Dependencies(name) {
+		if result := o.results[dependency]; result != nil && result.Result != "applied" && result.Result != "no-changes" {
+			return "dependency '" + dependency + "' " + result.Result
+		}
+	}
+
+	return ""
+}
+
+// setResult saves the result of the workspace
+func (o *orchestration) setResult(result *orchestrationResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.results[result.Workspace] = result
+	if result.Result == "failed" {
+		o.failed = true
+	}
+	fmt.Printf("%s  %s: %s %s\n", time.Now().Format("15:04:05"), result.Workspace, result.Result, result.Message)
+}
+
+// applyWorkspace queues a run in the workspace (or picks up the run queued by run trigger) and waits until it is applied
+func (o *orchestration) applyWorkspace(ctx context.Context, workspace *tfe.Workspace) *orchestrationResult {
+	c := o.options.TClient
+	result := &orchestrationResult{Workspace: workspace.Name, Result: "failed"}
+
+	ctx, cancel := context.WithTimeout(ctx, o.options.timeout)
+	defer cancel()
+
+	run, err := o.triggeredRun(ctx, workspace)
+	if err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	action := "picked up run queued by run trigger"
+	if run == nil {
+		action = "queued run"
+		createOptions := tfe.RunCreateOptions{Workspace: workspace, Message: tfe.String(o.options.message)}
+		if o.options.autoApply {
+			createOptions.AutoApply = tfe.Bool(true)
+		}
+		if run, err = c.Runs.Create(ctx, createOptions); err != nil {
+			result.Message = err.Error()
+			return result
+		}
+	}
+	result.RunID = run.ID
+	fmt.Printf("%s  %s: %s %s\n", time.Now().Format("15:04:05"),
+		workspace.Name, action, runstate.URL(o.options.TerraformHostname, o.options.TerraformOrganization, workspace.Name, run.ID))
+
+	if run, err = o.waitApplied(ctx, workspace.Name, run.ID); err != nil {
+		result.Message = err.Error()
+		return result
+	}
+
+	switch run.Status {
+	case tfe.RunApplied:
+		result.Result = "applied"
+	case tfe.RunPlannedAndFinished:
+		result.Result = "no-changes"
+	default:
+		result.Message = "run finished with status '" + string(run.Status) + "'"
+	}
+
+	return result
+}
+
+// triggeredRun returns the run queued in the workspace by run trigger during orchestration. If the workspace
+// depends by run trigger on a workspace applied during orchestration, it waits for such run until the context
+// is done instead of queueing a duplicate one, otherwise returns nil if there is no such run
+func (o *orchestration) triggeredRun(ctx context.Context, workspace *tfe.Workspace) (*tfe.Run, error) {
+	expected := false
+	o.mu.Lock()
+	for _, edge := range o.graph.Edges() {
+		if edge.To == workspace.Name && edge.Kind == graph.RunTrigger && o.results[edge.From] != nil && o.results[edge.From].Result == "applied" {
+			expected = true
+		}
+	}
+	o.mu.Unlock()
+
+	for {
+		run, err := o.findTriggeredRun(ctx, workspace)
+		if err != nil || run != nil || !expected {
+			return run, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no run was queued by run trigger: %w", ctx.Err())
+		case <-time.After(runstate.DefaultInterval):
+		}
+	}
+}
+
+// findTriggeredRun returns the newest non-speculative run queued by run trigger in the workspace
+// after orchestration started, or nil if there is no such run
+func (o *orchestration) findTriggeredRun(ctx context.Context, workspace *tfe.Workspace) (*tfe.Run, error) {
+	runs, err := o.options.TClient.Runs.List(ctx, workspace.ID, &tfe.RunListOptions{ListOptions: tfe.ListOptions{PageSize: 20}})
+	if err != nil {
+		return nil, err
+	}
+
+	// Runs are sorted from the newest to the oldest one
+	for _, run := range runs.Items {
+		if run.CreatedAt.Before(o.startedAt) {
+			break
+		}
+		if run.Source == runSourceRunTrigger && !run.PlanOnly {
+			return run, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// waitApplied waits until the run is finished, confirming it with --auto-apply
+func (o *orchestration) waitApplied(ctx context.Context, workspaceName, runID string) (*tfe.Run, error) {
+	c := o.options.TClient
+
+	run, err := runstate.Wait(ctx, c, runID, runstate.WaitOptions{})
+	if err != nil || !runstate.NeedsAction(run) {
+		return run, err
+	}
+
+	if o.options.autoApply && run.Actions != nil && run.Actions.IsConfirmable {
+		if err := c.Runs.Apply(ctx, runID, tfe.RunApplyOptions{Comment: tfe.String("Applied by tfctl run orchestrate")}); err != nil {
+			return run, err
+		}
+	} else {
+		fmt.Printf("%s  %s: run '%s' is waiting for confirmation or policy override\n", time.Now().Format("15:04:05"), workspaceName, run.ID)
+	}
+
+	return runstate.Wait(ctx, c, runID, runstate.WaitOptions{UntilFinal: true})
+}
+
+// summary prints results of all the workspaces in order and returns error if any of them failed or was skipped
+func (o *orchestration) summary(levels [][]string) error {
+	results := make([]*orchestrationResult, 0, len(o.results))
+	for _, level := range levels {
+		for _, name := range level {
+			results = append(results, o.results[name])
+		}
+	}
+
+	if o.options.Expand {
+		output.JsonOutput(results)
+	} else {
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []string{fmt.Sprint(result.Level), result.Workspace, result.Result, result.RunID, result.Duration.String(), result.Message})
+		}
+		fmt.Println()
+		output.TableOutput([]string{"LEVEL", "WORKSPACE", "RESULT", "RUN", "DURATION", "MESSAGE"}, rows)
+	}
+
+	failed, skipped := 0, 0
+	for _, result := range results {
+		switch result.Result {
+		case "failed":
+			failed++
+		case "skipped":
+			skipped++
+		}
+	}
+	if failed > 0 || skipped > 0 {
+		return fmt.Errorf("orchestration finished with %d failed and %d skipped workspace(s)", failed, skipped)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// overridePolicyOptions represents options for override-policy command
+type overridePolicyOptions struct {
+	*runOptions
+	workspaceName string
+	comment       string
+	yes           bool
+}
+
+// NewRunOverridePolicyCmd returns new run override-policy command
+func NewRunOverridePolicyCmd(runOptions *runOptions) *cobra.Command {
+	options := &overridePolicyOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "override-policy [run-id]",
+		Short:   "override soft-mandatory policy failures of a run",
+		Long:    "override soft-mandatory policy failures of a run with a justification comment, so the run can continue",
+		Example: "tfctl run override-policy run-CZcmD7eagjhyX0vN --comment=\"Approved by security team, JIRA-123\"",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return overridePolicy(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the current run of the workspace instead of run ID")
+	cmd.Flags().StringVarP(&options.comment, "comment", "c", "", "Required: Justification of the override added to the run")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	if err := cmd.MarkFlagRequired("comment"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func overridePolicy(cmd *cobra.Command, options *overridePolicyOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if strings.TrimSpace(options.comment) == "" {
+		return errors.New("justification comment can't be empty")
+	}
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, true)
+	if err != nil {
+		return err
+	}
+
+	run, err := c.Runs.Read(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	// Legacy Sentinel policy checks or policy evaluations in task stages may wait for override
+	policyChecks, err := overridablePolicyChecks(ctx, c, run.ID)
+	if err != nil {
+		return err
+	}
+	taskStages, err := overridableTaskStages(ctx, c, run.ID)
+	if err != nil {
+		return err
+	}
+	if len(policyChecks) == 0 && len(taskStages) == 0 {
+		return fmt.Errorf("run '%s' has no soft-mandatory policy failures to override in status '%s'", run.ID, run.Status)
+	}
+
+	if !options.yes {
+		fmt.Printf("Run '%s' (%s): %s\n", run.ID, run.Status, run.Message)
+		fmt.Printf("Justification: %s\n", options.comment)
+
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Do you want to override policy failures of the run?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Policy failures of run '" + run.ID + "' were not overridden")
+			return nil
+		}
+	}
+
+	if len(policyChecks) > 0 {
+		// Policy check override doesn't accept a comment, so the justification is added to the run
+		if _, err := c.Comments.Create(ctx, run.ID, tfe.CommentCreateOptions{Body: options.comment}); err != nil {
+			return err
+		}
+		for _, policyCheck := range policyChecks {
+			if _, err := c.PolicyChecks.Override(ctx, policyCheck.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, taskStage := range taskStages {
+		if _, err := c.TaskStages.Override(ctx, taskStage.ID, tfe.TaskStageOverrideOptions{Comment: tfe.String(options.comment)}); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Policy failures of run '" + run.ID + "' overridden successfully!")
+
+	return nil
+}
+
+// overridablePolicyChecks returns soft failed policy checks of the run which can be overridden
+func overridablePolicyChecks(ctx context.Context, c *tfe.Client, runID string) ([]*tfe.PolicyCheck, error) {
+	policyCheckList, err := c.PolicyChecks.List(ctx, runID, &tfe.PolicyCheckListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	var policyChecks []*tfe.PolicyCheck
+	for _, policyCheck := range policyCheckList.Items {
+		if policyCheck.Status != tfe.PolicySoftFailed || policyCheck.Actions == nil || !policyCheck.Actions.IsOverridable {
+			continue
+		}
+		if policyCheck.Permissions != nil && !policyCheck.Permissions.CanOverride {
+			return nil, fmt.Errorf("not enough permissions to override policy check '%s'", policyCheck.ID)
+		}
+		policyChecks = append(policyChecks, policyCheck)
+	}
+
+	return policyChecks, nil
+}
+
+// overridableTaskStages returns task stages of the run which are waiting for policy override
+func overridableTaskStages(ctx context.Context, c *tfe.Client, runID string) ([]*tfe.TaskStage, error) {
+	taskStageList, err := c.TaskStages.List(ctx, runID, &tfe.TaskStageListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	var taskStages []*tfe.TaskStage
+	for _, taskStage := range taskStageList.Items {
+		if taskStage.Status != tfe.TaskStageAwaitingOverride {
+			continue
+		}
+		if taskStage.Permissions != nil && taskStage.Permissions.CanOverridePolicy != nil && !*taskStage.Permissions.CanOverridePolicy {
+			return nil, fmt.Errorf("not enough permissions to override policies of task stage '%s'", taskStage.ID)
+		}
+		taskStages = append(taskStages, taskStage)
+	}
+
+	return taskStages, nil
+}
@@ -0,0 +1,249 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/pkg/runlog"
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-slug"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// planOptions represents options for plan command
+type planOptions struct {
+	*runOptions
+	workspaceName    string
+	dir              string
+	message          string
+	targets          []string
+	variables        []string
+	timeout          time.Duration
+	noColor          bool
+	detailedExitCode bool
+}
+
+// NewRunPlanCmd returns new run plan command
+func NewRunPlanCmd(runOptions *runOptions) *cobra.Command {
+	options := &planOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "run a speculative plan of local configuration in a workspace",
+		Long: "pack local configuration directory (honouring .terraformignore), upload it as a speculative configuration " +
+			"version of a workspace, queue a plan and stream its logs. The directory is uploaded as is, so for workspaces " +
+			"with working directory set it must be the repository root",
+		Example: "tfctl run plan [--dir=./infra] [--workspace=...] [--message=...] [--target=...] [--var key=value] [--detailed-exitcode]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return planConfiguration(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().StringVarP(&options.dir, "dir", "d", ".", "Optional: Directory with terraform configuration to upload")
+	cmd.Flags().StringVarP(&options.message, "message", "m", "Speculative plan queued by tfctl", "Optional: Message describing the run")
+	cmd.Flags().StringArrayVar(&options.targets, "target", nil, "Optional: Resource address to target, can be repeated")
+	cmd.Flags().StringArrayVar(&options.variables, "var", nil,
+		"Optional: Run-scoped variable in 'key=value' format (value is HCL literal or plain string), can be repeated")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", time.Hour, "Optional: Maximum time to wait for the plan to finish")
+	cmd.Flags().BoolVar(&options.noColor, "no-color", false, "Optional: Strip ANSI colors from the logs")
+	cmd.Flags().BoolVar(&options.detailedExitCode, "detailed-exitcode", false,
+		"Optional: Exit with code 0 if there are no changes, 1 on error and 2 if there are changes (like 'terraform plan')")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func planConfiguration(cobraCmd *cobra.Command, options *planOptions) error {
+	c := options.TClient
+
+	// The timeout covers processing of the uploaded configuration as well as the plan
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+	defer cancel()
+
+	variables, err := utils.ParseRunVariables(options.variables)
+	if err != nil {
+		return err
+	}
+
+	// Pack configuration directory into a slug
+	archive, meta, err := packConfiguration(options.dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cobraCmd.ErrOrStderr(), "Packed %d file(s) from '%s' (%d bytes)\n", len(meta.Files), options.dir, meta.Size)
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	configurationVersion, err := uploadConfiguration(ctx, c, workspace.ID, archive)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("configuration wasn't processed within %s", options.timeout)
+		}
+		return err
+	}
+
+	// Create a new plan-only run with uploaded configuration version
+	run, err := c.Runs.Create(ctx, tfe.RunCreateOptions{
+		Workspace:            workspace,
+		ConfigurationVersion: configurationVersion,
+		Message:              tfe.String(options.message),
+		PlanOnly:             tfe.Bool(true),
+		TargetAddrs:          options.targets,
+		Variables:            variables,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cobraCmd.ErrOrStderr(), "Run '"+run.ID+"' queued in workspace '"+workspace.Name+"'")
+	fmt.Fprintln(cobraCmd.ErrOrStderr(), runstate.URL(options.TerraformHostname, options.TerraformOrganization, workspace.Name, run.ID))
+
+	// Stream plan logs until the plan is finished
+	plan, err := waitForPlan(ctx, c, run.Plan.ID, true)
+	if err != nil {
+		return planWaitError(c, run.ID, options.timeout, err)
+	}
+	if planStarted(plan.Status) {
+		if err := copyLogs(ctx, cobraCmd.OutOrStdout(), runlog.Options{StripColors: options.noColor}, plan.ID, c.Plans.Logs); err != nil {
+			return planWaitError(c, run.ID, options.timeout, err)
+		}
+	}
+
+	// Plan-only run needing an action (e.g. policy override) is never applied, so waiting stops there
+	runID := run.ID
+	run, err = runstate.Wait(ctx, c, runID, runstate.WaitOptions{})
+	if err != nil {
+		return planWaitError(c, runID, options.timeout, err)
+	}
+
+	return planOutcome(cobraCmd.ErrOrStderr(), run, options.detailedExitCode)
+}
+
+// packConfiguration packs the directory into a tar gzip archive honouring .terraformignore rules
+func packConfiguration(dir string) (*bytes.Buffer, *slug.Meta, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("'%s' is not a directory", dir)
+	}
+
+	packer, err := slug.NewPacker(slug.ApplyTerraformIgnore(), slug.DereferenceSymlinks())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	archive := &bytes.Buffer{}
+	meta, err := packer.Pack(dir, archive)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to pack '%s': %w", dir, err)
+	}
+
+	return archive, meta, nil
+}
+
+// uploadConfiguration creates a speculative configuration version, uploads the archive and waits until it is processed
+func uploadConfiguration(ctx context.Context, c *tfe.Client, workspaceID string, archive *bytes.Buffer) (*tfe.ConfigurationVersion, error) {
+	configurationVersion, err := c.ConfigurationVersions.Create(ctx, workspaceID, tfe.ConfigurationVersionCreateOptions{
+		AutoQueueRuns: tfe.Bool(false),
+		Speculative:   tfe.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ConfigurationVersions.UploadTarGzip(ctx, configurationVersion.UploadURL, archive); err != nil {
+		return nil, err
+	}
+
+	// Uploaded configuration is processed asynchronously
+	for {
+		configurationVersion, err = c.ConfigurationVersions.Read(ctx, configurationVersion.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch configurationVersion.Status {
+		case tfe.ConfigurationUploaded:
+			return configurationVersion, nil
+		case tfe.ConfigurationErrored:
+			return nil, fmt.Errorf("configuration version '%s' errored: %s", configurationVersion.ID, configurationVersion.ErrorMessage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// planWaitError stops the run, so it doesn't stay in the workspace queue, and returns error
+// describing timeout of the plan or the original error
+func planWaitError(c *tfe.Client, runID string, timeout time.Duration, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("run '%s' didn't finish within %s", runID, timeout)
+	}
+
+	// The plan context may be already done
+	if stopErr := runstate.Stop(context.Background(), c, runID, "Speculative plan by tfctl is interrupted"); stopErr != nil {
+		return fmt.Errorf("%v, the run is not stopped: %v", err, stopErr)
+	}
+
+	return err
+}
+
+// planOutcome prints the plan summary and returns error if the plan failed or,
+// with detailed exit code, if it has changes
+func planOutcome(out io.Writer, run *tfe.Run, detailedExitCode bool) error {
+	switch run.Status {
+	case tfe.RunPlannedAndFinished:
+	case tfe.RunPolicySoftFailed, tfe.RunPostPlanAwaitingDecision, tfe.RunErrored, tfe.RunCanceled, tfe.RunDiscarded:
+		return fmt.Errorf("run '%s' finished with status '%s'", run.ID, run.Status)
+	default:
+		fmt.Fprintln(out, "Run '"+run.ID+"' finished with status '"+string(run.Status)+"'")
+	}
+
+	if !run.HasChanges {
+		fmt.Fprintln(out, "No changes. Infrastructure matches the configuration.")
+		return nil
+	}
+	if detailedExitCode {
+		return &cmd.ExitError{Code: runstate.ExitPlannedChanges, Err: fmt.Errorf("run '%s' has changes", run.ID)}
+	}
+
+	return nil
+}
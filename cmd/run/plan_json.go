@@ -0,0 +1,101 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// planJSONOptions represents options for plan-json command
+type planJSONOptions struct {
+	*runOptions
+	workspaceName string
+	outputPath    string
+}
+
+// NewRunPlanJSONCmd returns new run plan-json command
+func NewRunPlanJSONCmd(runOptions *runOptions) *cobra.Command {
+	options := &planJSONOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "plan-json [run-id]",
+		Short:   "download JSON execution plan of a run",
+		Long:    "download JSON execution plan (terraform show -json) of a run, sensitive values are redacted by the API",
+		Example: "tfctl run plan-json run-CZcmD7eagjhyX0vN [--output=plan.json]\ntfctl run plan-json --workspace=... > plan.json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return downloadPlanJSON(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+	cmd.Flags().StringVarP(&options.outputPath, "output", "o", "", "Optional: File to write JSON plan to instead of stdout")
+
+	return cmd
+}
+
+func downloadPlanJSON(cmd *cobra.Command, options *planJSONOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	data, err := readPlanJSON(ctx, c, runID)
+	if err != nil {
+		return err
+	}
+
+	if options.outputPath == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(options.outputPath, data, 0o600); err != nil {
+		return err
+	}
+	fmt.Println("JSON plan of run '" + runID + "' saved to '" + options.outputPath + "'")
+
+	return nil
+}
+
+// readPlanJSON returns JSON execution plan of the run
+func readPlanJSON(ctx context.Context, c *tfe.Client, runID string) ([]byte, error) {
+	run, err := c.Runs.Read(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Plan == nil {
+		return nil, fmt.Errorf("run '%s' has no plan", runID)
+	}
+
+	// Read JSON execution plan of the run plan
+	data, err := c.Plans.ReadJSONOutput(ctx, run.Plan.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON plan of run '%s' in status '%s': %w", runID, run.Status, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/policy"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// policyChecksOptions represents options for policy-checks command
+type policyChecksOptions struct {
+	*runOptions
+	workspaceName string
+	noLogs        bool
+}
+
+// policyReport represents results of a policy check (Sentinel) or a policy evaluation (OPA, Sentinel) of a run
+type policyReport struct {
+	ID       string          `json:"id"`
+	Kind     string          `json:"kind"`
+	Stage    string          `json:"stage,omitempty"`
+	Status   string          `json:"status"`
+	Policies []policy.Result `json:"policies"`
+	Logs     string          `json:"logs,omitempty"`
+}
+
+// NewRunPolicyChecksCmd returns new run policy-checks command
+func NewRunPolicyChecksCmd(runOptions *runOptions) *cobra.Command {
+	options := &policyChecksOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "policy-checks [run-id]",
+		Aliases: []string{"policies"},
+		Short:   "show policy check results of a run",
+		Long:    "show result and enforcement level of each policy evaluated against a run with policy logs",
+		Example: "tfctl run policy-checks run-CZcmD7eagjhyX0vN [--no-logs]\ntfctl run policy-checks --workspace=...",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showPolicyChecks(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+	cmd.Flags().BoolVar(&options.noLogs, "no-logs", false, "Optional: Don't print policy logs")
+
+	return cmd
+}
+
+func showPolicyChecks(_ *cobra.Command, options *policyChecksOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	reports, err := policyCheckReports(ctx, c, runID, !options.noLogs)
+	if err != nil {
+		return err
+	}
+	evaluations, err := policyEvaluationReports(ctx, c, runID)
+	if err != nil {
+		return err
+	}
+	reports = append(reports, evaluations...)
+
+	if options.Expand {
+		output.JsonOutput(reports)
+		return nil
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("Run '" + runID + "' has no policy checks")
+		return nil
+	}
+	for i, report := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		printPolicyReport(report, !options.noLogs)
+	}
+
+	return nil
+}
+
+// policyCheckReports returns results of Sentinel policy checks of the run
+func policyCheckReports(ctx context.Context, c *tfe.Client, runID string, withLogs bool) ([]*policyReport, error) {
+	policyCheckList, err := c.PolicyChecks.List(ctx, runID, &tfe.PolicyCheckListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*policyReport, 0, len(policyCheckList.Items))
+	for _, policyCheck := range policyCheckList.Items {
+		report := &policyReport{
+			ID:     policyCheck.ID,
+			Kind:   string(tfe.Sentinel),
+			Status: string(policyCheck.Status),
+		}
+		if policyCheck.Result != nil {
+			if report.Policies, err = policy.ParseSentinel(policyCheck.Result.Sentinel); err != nil {
+				return nil, err
+			}
+		}
+
+		if withLogs {
+			logs, err := c.PolicyChecks.Logs(ctx, policyCheck.ID)
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(logs)
+			if err != nil {
+				return nil, err
+			}
+			report.Logs = string(data)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// policyEvaluationReports returns results of policy evaluations in task stages of the run
+func policyEvaluationReports(ctx context.Context, c *tfe.Client, runID string) ([]*policyReport, error) {
+	taskStageList, err := c.TaskStages.List(ctx, runID, &tfe.TaskStageListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []*policyReport
+	for _, taskStage := range taskStageList.Items {
+		evaluationList, err := c.PolicyEvaluations.List(ctx, taskStage.ID, &tfe.PolicyEvaluationListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, evaluation := range evaluationList.Items {
+			report := &policyReport{
+				ID:     evaluation.ID,
+				Kind:   string(evaluation.PolicyKind),
+				Stage:  string(taskStage.Stage),
+				Status: string(evaluation.Status),
+			}
+
+			outcomeList, err := c.PolicySetOutcomes.List(ctx, evaluation.ID, &tfe.PolicySetOutcomeListOptions{ListOptions: &tfe.ListOptions{PageSize: 100}})
+			if err != nil {
+				return nil, err
+			}
+
+			var logs strings.Builder
+			for _, setOutcome := range outcomeList.Items {
+				if setOutcome.Error != "" {
+					fmt.Fprintf(&logs, "%s: %s\n", setOutcome.PolicySetName, setOutcome.Error)
+				}
+				for _, outcome := range setOutcome.Outcomes {
+					report.Policies = append(report.Policies, policy.Result{
+						PolicySet:   setOutcome.PolicySetName,
+						Policy:      outcome.PolicyName,
+						Enforcement: string(outcome.EnforcementLevel),
+						Passed:      outcome.Status == "passed",
+					})
+					for _, line := range outcome.Output {
+						fmt.Fprintf(&logs, "%s: %s\n", outcome.PolicyName, line.Print)
+					}
+				}
+			}
+			report.Logs = logs.String()
+			reports = append(reports, report)
+		}
+	}
+
+	return reports, nil
+}
+
+func printPolicyReport(report *policyReport, withLogs bool) {
+	stage := ""
+	if report.Stage != "" {
+		stage = ", stage " + report.Stage
+	}
+	fmt.Printf("%s (%s%s): %s\n", report.ID, report.Kind, stage, report.Status)
+
+	rows := make([][]string, 0, len(report.Policies))
+	for _, result := range report.Policies {
+		status := "passed"
+		if !result.Passed {
+			status = "failed"
+		}
+		if result.Error != "" {
+			status = "errored: " + result.Error
+		}
+		rows = append(rows, []string{result.PolicySet, result.Policy, result.Enforcement, status})
+	}
+	output.TableOutput([]string{"POLICY-SET", "POLICY", "ENFORCEMENT", "RESULT"}, rows)
+
+	if withLogs && strings.TrimSpace(report.Logs) != "" {
+		fmt.Printf("\n%s\n", strings.TrimRight(report.Logs, "\n"))
+	}
+}
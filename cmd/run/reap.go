@@ -0,0 +1,244 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// reapOptions represents options for reap command
+type reapOptions struct {
+	*runOptions
+	selector  string
+	olderThan string
+	status    string
+	comment   string
+	workers   int
+	discard   bool
+	cancel    bool
+	yes       bool
+}
+
+// reapResult represents a stale run with the outcome of the action on it
+type reapResult struct {
+	Workspace string        `json:"workspace"`
+	RunID     string        `json:"run-id"`
+	Status    tfe.RunStatus `json:"status"`
+	CreatedAt time.Time     `json:"created-at"`
+	Result    string        `json:"result,omitempty"`
+}
+
+// NewRunReapCmd returns new run reap command
+func NewRunReapCmd(runOptions *runOptions) *cobra.Command {
+	options := &reapOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "reap",
+		Short: "list stale runs blocking workspace queues and discard or cancel them",
+		Long: "list runs left in the given statuses for longer than the given time within an organization (or selected workspaces) " +
+			"and discard or cancel them concurrently, reporting the outcome of each run",
+		Example: "tfctl run reap [--older-than=48h] [--status=planned,pending] [--selector=...] [--discard|--cancel] [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reapRuns(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"Optional: workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cmd.Flags().StringVar(&options.olderThan, "older-than", "48h", "Optional: Reap runs created before the time (e.g. '48h', '7d', '2006-01-02')")
+	cmd.Flags().StringVar(&options.status, "status", "planned,pending", "Optional: Comma separated list of run statuses to reap")
+	cmd.Flags().StringVarP(&options.comment, "comment", "c", "Stale run reaped by tfctl", "Optional: Comment to add to the discarded or canceled runs")
+	cmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of runs processed concurrently")
+	cmd.Flags().BoolVar(&options.discard, "discard", false, "Optional: Discard the stale runs")
+	cmd.Flags().BoolVar(&options.cancel, "cancel", false, "Optional: Cancel the stale runs")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	cmd.MarkFlagsMutuallyExclusive("discard", "cancel")
+
+	return cmd
+}
+
+func reapRuns(cmd *cobra.Command, options *reapOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	before, err := utils.ParseSince(options.olderThan, time.Now())
+	if err != nil {
+		return err
+	}
+
+	runs, err := listStaleRuns(ctx, c, options, before)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println("No stale runs found")
+		return nil
+	}
+
+	var action runAction
+	switch {
+	case options.discard:
+		action = discardAction
+	case options.cancel:
+		action = cancelAction
+	default:
+		printReapResults(cmd.OutOrStdout(), options.Expand, runs, nil)
+		return nil
+	}
+
+	if !options.yes {
+		// Stdout gets only the final result in json format, runs to confirm are listed on stderr
+		out := cmd.OutOrStdout()
+		if options.Expand {
+			out = cmd.ErrOrStderr()
+		}
+		printReapResults(out, false, runs, nil)
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), out, fmt.Sprintf("Reap (%s) %d stale run(s)?", action.use, len(runs)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Reaping of stale runs canceled")
+			return nil
+		}
+	}
+
+	var mu sync.Mutex
+	results := map[string]string{}
+	errs := worker.Run(ctx, runs, worker.Options{Workers: options.workers}, func(ctx context.Context, run *tfe.Run) error {
+		result := action.done
+		if !action.allowed(run) {
+			result = "skipped: can't " + action.use + " run in status '" + string(run.Status) + "'"
+		} else if err := action.do(ctx, c, run.ID, tfe.String(options.comment)); err != nil {
+			result = "failed: " + err.Error()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		results[run.ID] = result
+		if result != action.done {
+			return fmt.Errorf("run '%s' %s", run.ID, result)
+		}
+		return nil
+	})
+	printReapResults(cmd.OutOrStdout(), options.Expand, runs, results)
+
+	if failed := len(errs); failed > 0 {
+		return fmt.Errorf("%d of %d stale run(s) were not %s", failed, len(runs), action.done)
+	}
+
+	return nil
+}
+
+// listStaleRuns returns runs in the statuses created before the time, in the selected workspaces only if selector is set
+func listStaleRuns(ctx context.Context, c *tfe.Client, options *reapOptions, before time.Time) ([]*tfe.Run, error) {
+	sel, err := selector.ParseOptional(options.selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected map[string]bool
+	if !sel.IsEmpty() {
+		workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+		if err != nil {
+			return nil, err
+		}
+		selected = map[string]bool{}
+		for _, workspace := range workspaces {
+			selected[workspace.ID] = true
+		}
+	}
+
+	fetch, err := runPageFetcher(ctx, c, &listOptions{runOptions: options.runOptions, status: options.status})
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*tfe.Run
+	for page := 1; page != 0; {
+		result, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		page = result.nextPage
+
+		for _, run := range result.runs {
+			if !run.CreatedAt.Before(before) {
+				continue
+			}
+			if selected != nil && (run.Workspace == nil || !selected[run.Workspace.ID]) {
+				continue
+			}
+			runs = append(runs, run)
+		}
+	}
+
+	return runs, nil
+}
+
+// printReapResults writes stale runs with their age and outcome of the action if any to w,
+// json is always printed to stdout
+func printReapResults(w io.Writer, expand bool, runs []*tfe.Run, results map[string]string) {
+	items := make([]*reapResult, 0, len(runs))
+	for _, run := range runs {
+		item := &reapResult{RunID: run.ID, Status: run.Status, CreatedAt: run.CreatedAt, Result: results[run.ID]}
+		if run.Workspace != nil {
+			item.Workspace = run.Workspace.Name
+		}
+		items = append(items, item)
+	}
+
+	if expand {
+		output.JsonOutput(items)
+		return
+	}
+
+	now := time.Now()
+	headers := []string{"WORKSPACE", "RUN", "STATUS", "CREATED", "AGE"}
+	if results != nil {
+		headers = append(headers, "RESULT")
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := []string{
+			item.Workspace,
+			item.RunID,
+			string(item.Status),
+			item.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			now.Sub(item.CreatedAt).Round(time.Minute).String(),
+		}
+		if results != nil {
+			row = append(row, item.Result)
+		}
+		rows = append(rows, row)
+	}
+	output.TableOutputTo(w, headers, rows)
+}
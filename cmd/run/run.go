@@ -0,0 +1,69 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"github.com/ealebed/tfctl/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+// Package describes the run related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run
+
+type runOptions struct {
+	*cmd.RootOptions
+}
+
+// NewRunCmd create new run command
+func NewRunCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &runOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:     "run",
+		Aliases: []string{"runs"},
+		Short:   "Work with terraform runs",
+		Long:    "Work with terraform runs",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewRunListCmd(options))
+	cobraCmd.AddCommand(NewRunStartCmd(options))
+	cobraCmd.AddCommand(NewRunPlanCmd(options))
+	cobraCmd.AddCommand(NewRunPlanJSONCmd(options))
+	cobraCmd.AddCommand(NewRunShowCmd(options))
+	cobraCmd.AddCommand(NewRunCostCmd(options))
+	cobraCmd.AddCommand(NewRunCostReportCmd(options))
+	cobraCmd.AddCommand(NewRunLogsCmd(options))
+	cobraCmd.AddCommand(NewRunApplyCmd(options))
+	cobraCmd.AddCommand(NewRunDiscardCmd(options))
+	cobraCmd.AddCommand(NewRunCancelCmd(options))
+	cobraCmd.AddCommand(NewRunForceCancelCmd(options))
+	cobraCmd.AddCommand(NewRunWaitCmd(options))
+	cobraCmd.AddCommand(NewRunPolicyChecksCmd(options))
+	cobraCmd.AddCommand(NewRunOverridePolicyCmd(options))
+	cobraCmd.AddCommand(NewRunOrchestrateCmd(options))
+	cobraCmd.AddCommand(NewRunCommentCmd(options))
+	cobraCmd.AddCommand(NewRunEventsCmd(options))
+	cobraCmd.AddCommand(NewRunReapCmd(options))
+
+	return cobraCmd
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/planjson"
+	"github.com/ealebed/tfctl/pkg/runstate"
+
+	"github.com/fatih/color"
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// showOptions represents options for show command
+type showOptions struct {
+	*runOptions
+	workspaceName string
+	failOnDestroy bool
+}
+
+// actionSymbols are prefixes of resource addresses in changes summary
+var actionSymbols = map[planjson.Action]string{
+	planjson.Create:  "+",
+	planjson.Update:  "~",
+	planjson.Replace: "-/+",
+	planjson.Delete:  "-",
+	planjson.Read:    "<=",
+}
+
+// NewRunShowCmd returns new run show command
+func NewRunShowCmd(runOptions *runOptions) *cobra.Command {
+	options := &showOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show [run-id]",
+		Aliases: []string{"get"},
+		Short:   "show a run with summary of planned resource changes",
+		Long:    "show a run with summary of planned resource changes grouped by action (create, update, replace, delete)",
+		Example: "tfctl run show run-CZcmD7eagjhyX0vN [--fail-on-destroy]\ntfctl run show --workspace=...",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showRun(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the latest run of the workspace instead of run ID")
+	cmd.Flags().BoolVar(&options.failOnDestroy, "fail-on-destroy", false, "Optional: Return error if the plan destroys or replaces any resource")
+
+	return cmd
+}
+
+func showRun(_ *cobra.Command, options *showOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, false)
+	if err != nil {
+		return err
+	}
+
+	// Read a run with its plan and workspace
+	run, err := c.Runs.ReadWithOptions(ctx, runID, &tfe.RunReadOptions{Include: []tfe.RunIncludeOpt{tfe.RunPlan, tfe.RunWorkspace}})
+	if err != nil {
+		return err
+	}
+
+	data, err := readPlanJSON(ctx, c, run.ID)
+	if err != nil {
+		return err
+	}
+	plan, err := planjson.Parse(data)
+	if err != nil {
+		return err
+	}
+	summary := plan.Summarize()
+
+	if options.Expand {
+		output.JsonOutput(summary)
+	} else {
+		printRunSummary(run, summary, options)
+	}
+
+	if options.failOnDestroy && summary.Destroys() > 0 {
+		return fmt.Errorf("plan of run '%s' destroys %d resource(s)", run.ID, summary.Destroys())
+	}
+
+	return nil
+}
+
+func printRunSummary(run *tfe.Run, summary planjson.Summary, options *showOptions) {
+	destroy := color.New(color.FgRed, color.Bold).SprintFunc()
+
+	fmt.Printf("Run:       %s\n", run.ID)
+	if run.Workspace != nil {
+		fmt.Printf("Workspace: %s\n", run.Workspace.Name)
+		fmt.Printf("URL:       %s\n", runstate.URL(options.TerraformHostname, options.TerraformOrganization, run.Workspace.Name, run.ID))
+	}
+	fmt.Printf("Status:    %s\n", run.Status)
+	fmt.Printf("Message:   %s\n", run.Message)
+
+	for _, action := range planjson.Actions {
+		addresses := summary[action]
+		if action == planjson.NoOp || len(addresses) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s (%d):\n", action, len(addresses))
+		for _, address := range addresses {
+			line := actionSymbols[action] + " " + address
+			if action == planjson.Delete || action == planjson.Replace {
+				line = destroy(line)
+			}
+			fmt.Printf("  %s\n", line)
+		}
+	}
+
+	fmt.Printf("\nPlan: %s\n", runstate.PlanSummary(run.Plan))
+	if summary.Destroys() > 0 {
+		fmt.Println(destroy(fmt.Sprintf("WARNING: %d resource(s) will be destroyed", summary.Destroys())))
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// startOptions represents options for start command
+type startOptions struct {
+	*runOptions
+	workspaceName string
+	message       string
+	targets       []string
+	replaces      []string
+	variables     []string
+	refreshOnly   bool
+	destroy       bool
+	planOnly      bool
+	autoApply     bool
+	follow        bool
+}
+
+// NewRunStartCmd returns new run start command
+func NewRunStartCmd(runOptions *runOptions) *cobra.Command {
+	options := &startOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "start",
+		Aliases: []string{"queue", "create"},
+		Short:   "queue a new run in a workspace",
+		Long:    "queue a new run in a workspace using its latest configuration version and print the run ID and URL",
+		Example: "tfctl run start [--workspace=...] [--message=...] [--target=...] [--replace=...] [--var key=value] [--follow]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return startRun(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().StringVarP(&options.message, "message", "m", "Queued by tfctl", "Optional: Message describing the run")
+	cmd.Flags().StringArrayVar(&options.targets, "target", nil, "Optional: Resource address to target, can be repeated")
+	cmd.Flags().StringArrayVar(&options.replaces, "replace", nil, "Optional: Resource address to replace, can be repeated")
+	cmd.Flags().StringArrayVar(&options.variables, "var", nil,
+		"Optional: Run-scoped variable in 'key=value' format (value is HCL literal or plain string), can be repeated")
+	cmd.Flags().BoolVar(&options.refreshOnly, "refresh-only", false, "Optional: Only refresh the state without proposing changes")
+	cmd.Flags().BoolVar(&options.destroy, "destroy", false, "Optional: Plan destruction of all the resources")
+	cmd.Flags().BoolVar(&options.planOnly, "plan-only", false, "Optional: Speculative plan which can't be applied")
+	cmd.Flags().BoolVar(&options.autoApply, "auto-apply", false, "Optional: Apply the run automatically when plan succeeds")
+	cmd.Flags().BoolVarP(&options.follow, "follow", "f", false, "Optional: Follow the run status until it is finished or needs confirmation")
+	cmd.MarkFlagsMutuallyExclusive("refresh-only", "destroy")
+	cmd.MarkFlagsMutuallyExclusive("refresh-only", "replace")
+	cmd.MarkFlagsMutuallyExclusive("plan-only", "auto-apply")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func startRun(_ *cobra.Command, options *startOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	variables, err := utils.ParseRunVariables(options.variables)
+	if err != nil {
+		return err
+	}
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// Fill needed create options from https://pkg.go.dev/github.com/hashicorp/go-tfe#RunCreateOptions
+	createOptions := tfe.RunCreateOptions{
+		Workspace:    workspace,
+		Message:      tfe.String(options.message),
+		TargetAddrs:  options.targets,
+		ReplaceAddrs: options.replaces,
+		Variables:    variables,
+	}
+	if options.refreshOnly {
+		createOptions.RefreshOnly = tfe.Bool(true)
+	}
+	if options.destroy {
+		createOptions.IsDestroy = tfe.Bool(true)
+	}
+	if options.planOnly {
+		createOptions.PlanOnly = tfe.Bool(true)
+	}
+	if options.autoApply {
+		createOptions.AutoApply = tfe.Bool(true)
+	}
+
+	// Create a new run
+	run, err := c.Runs.Create(ctx, createOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Run '" + run.ID + "' queued in workspace '" + workspace.Name + "'")
+	fmt.Println(runstate.URL(options.TerraformHostname, options.TerraformOrganization, workspace.Name, run.ID))
+
+	if !options.follow {
+		return nil
+	}
+
+	return followRun(ctx, c, run.ID)
+}
+
+// followRun prints run status changes until the run is finished or needs an action,
+// returns error if the run didn't finish successfully
+func followRun(ctx context.Context, c *tfe.Client, runID string) error {
+	run, err := runstate.Wait(ctx, c, runID, runstate.WaitOptions{
+		OnStatus: func(run *tfe.Run) {
+			fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), run.Status)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if runstate.NeedsAction(run) {
+		fmt.Println("Run '" + run.ID + "' is waiting for confirmation or policy override")
+		return nil
+	}
+
+	switch run.Status {
+	case tfe.RunErrored, tfe.RunCanceled, tfe.RunDiscarded:
+		return fmt.Errorf("run '%s' finished with status '%s'", run.ID, run.Status)
+	}
+	fmt.Println("Run '" + run.ID + "' finished with status '" + string(run.Status) + "'")
+
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// addRunTargetFlags adds '--workspace' flag for commands working with a run given by its ID or a run of a workspace
+func addRunTargetFlags(cmd *cobra.Command, workspaceName *string, usage string) {
+	cmd.Flags().StringVarP(workspaceName, "workspace", "w", "", usage)
+}
+
+// resolveRunID returns run ID given as an argument or ID of the run of the workspace:
+// the current one if current is set or the latest one (including speculative plans) otherwise
+func resolveRunID(ctx context.Context, c *tfe.Client, organization string, args []string, workspaceName string, current bool) (string, error) {
+	switch {
+	case len(args) > 0 && workspaceName != "":
+		return "", fmt.Errorf("either run ID or --workspace must be provided, not both")
+	case len(args) > 0:
+		return args[0], nil
+	case workspaceName == "":
+		return "", fmt.Errorf("either run ID or --workspace must be provided")
+	}
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, organization, workspaceName)
+	if err != nil {
+		return "", err
+	}
+
+	if current {
+		if workspace.CurrentRun == nil {
+			return "", fmt.Errorf("workspace '%s' has no current run", workspaceName)
+		}
+		return workspace.CurrentRun.ID, nil
+	}
+
+	// List the runs of the workspace, the latest one comes first
+	runList, err := c.Runs.List(ctx, workspace.ID, &tfe.RunListOptions{ListOptions: tfe.ListOptions{PageSize: 1}})
+	if err != nil {
+		return "", err
+	}
+	if len(runList.Items) == 0 {
+		return "", fmt.Errorf("workspace '%s' has no runs", workspaceName)
+	}
+
+	return runList.Items[0].ID, nil
+}
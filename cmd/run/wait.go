@@ -0,0 +1,172 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/pkg/runstate"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// waitOptions represents options for wait command
+type waitOptions struct {
+	*runOptions
+	workspaceName string
+	timeout       time.Duration
+	interval      time.Duration
+	maxInterval   time.Duration
+	untilFinal    bool
+}
+
+// NewRunWaitCmd returns new run wait command
+func NewRunWaitCmd(runOptions *runOptions) *cobra.Command {
+	options := &waitOptions{
+		runOptions: runOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "wait [run-id]",
+		Short: "wait until a run is finished and exit with a code describing its outcome",
+		Long: fmt.Sprintf("wait until a run is finished (or needs confirmation) and exit with a code describing its outcome: "+
+			"%d applied, %d planned with changes, %d planned without changes, %d errored, %d discarded, %d policy failed, "+
+			"%d canceled, %d timeout, %d other errors",
+			runstate.ExitApplied, runstate.ExitPlannedChanges, runstate.ExitPlannedNoChanges, runstate.ExitErrored,
+			runstate.ExitDiscarded, runstate.ExitPolicyFailed, runstate.ExitCanceled, runstate.ExitTimeout, runstate.ExitError),
+		Example: "tfctl run wait run-CZcmD7eagjhyX0vN [--timeout=30m] [--interval=5s] [--max-interval=1m] [--until-final]",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return waitRun(cmd, options, args)
+		},
+	}
+
+	addRunTargetFlags(cmd, &options.workspaceName, "Optional: Use the current run of the workspace instead of run ID")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", 0, "Optional: Maximum time to wait (0 means no timeout)")
+	cmd.Flags().DurationVar(&options.interval, "interval", runstate.DefaultInterval, "Optional: Initial delay between two run status reads")
+	cmd.Flags().DurationVar(&options.maxInterval, "max-interval", time.Minute,
+		"Optional: Maximum delay between two run status reads, the delay grows while the status doesn't change")
+	cmd.Flags().BoolVar(&options.untilFinal, "until-final", false,
+		"Optional: Keep waiting while the run needs confirmation or policy override instead of exiting")
+
+	return cmd
+}
+
+func waitRun(_ *cobra.Command, options *waitOptions, args []string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runID, err := resolveRunID(ctx, c, options.TerraformOrganization, args, options.workspaceName, true)
+	if err != nil {
+		return err
+	}
+
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.timeout)
+		defer cancel()
+	}
+
+	run, err := runstate.Wait(ctx, c, runID, runstate.WaitOptions{
+		Interval:    options.interval,
+		MaxInterval: options.maxInterval,
+		UntilFinal:  options.untilFinal,
+		OnStatus: func(run *tfe.Run) {
+			fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), run.Status)
+		},
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &cmd.ExitError{Code: runstate.ExitTimeout, Err: fmt.Errorf("timed out after %s waiting for run '%s'", options.timeout, runID)}
+	}
+	if err != nil {
+		return err
+	}
+
+	policyFailed := false
+	if run.Status == tfe.RunErrored {
+		if policyFailed, err = hasHardFailedPolicies(context.Background(), c, run.ID); err != nil {
+			return err
+		}
+	}
+
+	code := runstate.ExitCode(run, policyFailed)
+	outcome := fmt.Sprintf("run '%s' finished with status '%s'", run.ID, run.Status)
+	if runstate.NeedsAction(run) {
+		outcome = fmt.Sprintf("run '%s' is waiting for confirmation or policy override in status '%s'", run.ID, run.Status)
+	}
+	if code == runstate.ExitApplied {
+		fmt.Println(outcome)
+		return nil
+	}
+
+	return &cmd.ExitError{Code: code, Err: errors.New(outcome)}
+}
+
+// hasHardFailedPolicies checks whether any of mandatory policies of the run failed,
+// both in legacy Sentinel policy checks and in policy evaluations of task stages
+func hasHardFailedPolicies(ctx context.Context, c *tfe.Client, runID string) (bool, error) {
+	policyCheckList, err := c.PolicyChecks.List(ctx, runID, &tfe.PolicyCheckListOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	for _, policyCheck := range policyCheckList.Items {
+		if policyCheck.Status == tfe.PolicyHardFailed {
+			return true, nil
+		}
+	}
+
+	// Policy evaluations are not available for every organization, which means there are no policy failures
+	taskStageList, err := c.TaskStages.List(ctx, runID, &tfe.TaskStageListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+	if err != nil {
+		if isUnavailable(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, taskStage := range taskStageList.Items {
+		evaluationList, err := c.PolicyEvaluations.List(ctx, taskStage.ID, &tfe.PolicyEvaluationListOptions{ListOptions: tfe.ListOptions{PageSize: 100}})
+		if err != nil {
+			if isUnavailable(err) {
+				continue
+			}
+			return false, err
+		}
+
+		for _, evaluation := range evaluationList.Items {
+			// Failed evaluation without result counts is treated as mandatory failure
+			if evaluation.Status == tfe.PolicyEvaluationFailed && (evaluation.ResultCount == nil || evaluation.ResultCount.MandatoryFailed > 0) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// isUnavailable returns true if the resource is not found or is forbidden for the token,
+// go-tfe doesn't have an error for 403 responses, so their 'forbidden' title is checked
+func isUnavailable(err error) bool {
+	return errors.Is(err, tfe.ErrResourceNotFound) || errors.Is(err, tfe.ErrUnauthorized) ||
+		strings.HasPrefix(strings.ToLower(err.Error()), "forbidden")
+}
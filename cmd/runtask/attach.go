@@ -0,0 +1,130 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// attachOptions represents options for attach command
+type attachOptions struct {
+	*runTaskOptions
+	runTaskName      string
+	workspaceName    string
+	enforcementLevel string
+	stages           []string
+}
+
+// NewRunTaskAttachCmd returns new run task attach command
+func NewRunTaskAttachCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &attachOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "attach",
+		Short:   "attach a run task to a workspace",
+		Long:    "attach a run task to a workspace (or update stages and enforcement level of existing attachment)",
+		Example: "tfctl runtask attach --name=... --workspace=... [--enforcement=mandatory] [--stage=post_plan]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return attachRunTask(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.runTaskName, "name", "n", "", "terraform run task name")
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name to attach the run task to")
+	cmd.Flags().StringVarP(&options.enforcementLevel, "enforcement", "e", string(tfe.Advisory),
+		"Optional: Enforcement level (advisory or mandatory), failed mandatory task stops the run")
+	cmd.Flags().StringSliceVar(&options.stages, "stage", []string{string(tfe.PostPlan)},
+		"Optional: Comma separated list of run stages (pre_plan, post_plan, pre_apply, post_apply)")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func attachRunTask(_ *cobra.Command, options *attachOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := utils.ValidateOneOf("enforcement level", options.enforcementLevel, string(tfe.Advisory), string(tfe.Mandatory)); err != nil {
+		return err
+	}
+	stages := make([]tfe.Stage, 0, len(options.stages))
+	for _, stage := range options.stages {
+		if err := utils.ValidateOneOf("stage", stage, string(tfe.PrePlan), string(tfe.PostPlan), string(tfe.PreApply), string(tfe.PostApply)); err != nil {
+			return err
+		}
+		stages = append(stages, tfe.Stage(stage))
+	}
+
+	runTaskID, err := readRunTaskID(ctx, c, options.TerraformOrganization, options.runTaskName)
+	if err != nil {
+		return err
+	}
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	workspaceRunTask, err := findWorkspaceRunTask(ctx, c, workspace.ID, runTaskID)
+	if err != nil {
+		return err
+	}
+
+	enforcementLevel := tfe.TaskEnforcementLevel(options.enforcementLevel)
+	if workspaceRunTask == nil {
+		// Attach a run task to the workspace
+		_, err = c.WorkspaceRunTasks.Create(ctx, workspace.ID, tfe.WorkspaceRunTaskCreateOptions{
+			EnforcementLevel: enforcementLevel,
+			RunTask:          &tfe.RunTask{ID: runTaskID},
+			Stages:           &stages,
+		})
+	} else {
+		// Update existing attachment of the run task
+		_, err = c.WorkspaceRunTasks.Update(ctx, workspace.ID, workspaceRunTask.ID, tfe.WorkspaceRunTaskUpdateOptions{
+			EnforcementLevel: enforcementLevel,
+			Stages:           &stages,
+		})
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Run task '%s' attached to workspace '%s' (%s, %s) successfully!\n",
+		options.runTaskName, options.workspaceName, options.enforcementLevel, joinStages(stages))
+
+	return nil
+}
+
+// joinStages returns comma separated list of stages
+func joinStages(stages []tfe.Stage) string {
+	names := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names = append(names, string(stage))
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteOptions represents options for delete command
+type deleteOptions struct {
+	*runTaskOptions
+	runTaskName string
+	yes         bool
+}
+
+// NewRunTaskDeleteCmd returns new run task delete command
+func NewRunTaskDeleteCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &deleteOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"del", "rm"},
+		Short:   "delete a run task from terraform organization",
+		Long:    "delete a run task from terraform organization, it's detached from all the workspaces",
+		Example: "tfctl runtask delete --name=... [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteRunTask(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.runTaskName, "name", "n", "", "terraform run task name to delete")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func deleteRunTask(cmd *cobra.Command, options *deleteOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runTaskID, err := readRunTaskID(ctx, c, options.TerraformOrganization, options.runTaskName)
+	if err != nil {
+		return err
+	}
+
+	if !options.yes {
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Delete run task '"+options.runTaskName+"'?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Deletion of run task '" + options.runTaskName + "' canceled")
+			return nil
+		}
+	}
+
+	// Delete a run task by its ID
+	if err := c.RunTasks.Delete(ctx, runTaskID); err != nil {
+		return err
+	}
+	fmt.Println("Run task '" + options.runTaskName + "' deleted successfully!")
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// detachOptions represents options for detach command
+type detachOptions struct {
+	*runTaskOptions
+	runTaskName   string
+	workspaceName string
+}
+
+// NewRunTaskDetachCmd returns new run task detach command
+func NewRunTaskDetachCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &detachOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "detach",
+		Short:   "detach a run task from a workspace",
+		Long:    "detach a run task from a workspace",
+		Example: "tfctl runtask detach --name=... --workspace=...",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return detachRunTask(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.runTaskName, "name", "n", "", "terraform run task name")
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name to detach the run task from")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func detachRunTask(_ *cobra.Command, options *detachOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runTaskID, err := readRunTaskID(ctx, c, options.TerraformOrganization, options.runTaskName)
+	if err != nil {
+		return err
+	}
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	workspaceRunTask, err := findWorkspaceRunTask(ctx, c, workspace.ID, runTaskID)
+	if err != nil {
+		return err
+	}
+	if workspaceRunTask == nil {
+		return fmt.Errorf("run task '%s' is not attached to workspace '%s'", options.runTaskName, options.workspaceName)
+	}
+
+	// Delete the attachment of the run task to the workspace
+	if err := c.WorkspaceRunTasks.Delete(ctx, workspace.ID, workspaceRunTask.ID); err != nil {
+		return err
+	}
+	fmt.Println("Run task '" + options.runTaskName + "' detached from workspace '" + options.workspaceName + "' successfully!")
+
+	return nil
+}
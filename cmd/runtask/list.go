@@ -0,0 +1,156 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// listOptions represents options for list command
+type listOptions struct {
+	*runTaskOptions
+	workspaceName string
+}
+
+// NewRunTaskListCmd returns new run task list command
+func NewRunTaskListCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &listOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list run tasks of an organization or run tasks attached to a workspace",
+		Long:    "list run tasks of an organization with number of attached workspaces, or run tasks attached to a workspace",
+		Example: "tfctl runtask list [--workspace=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listRunTasks(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "Optional: List run tasks attached to the workspace")
+
+	return cmd
+}
+
+func listRunTasks(_ *cobra.Command, options *listOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runTasks, err := listOrganizationRunTasks(ctx, c, options.TerraformOrganization)
+	if err != nil {
+		return err
+	}
+
+	if options.workspaceName != "" {
+		return listWorkspaceRunTasks(ctx, options, runTasks)
+	}
+
+	if options.Expand {
+		output.JsonOutput(runTasks)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(runTasks))
+	for _, runTask := range runTasks {
+		rows = append(rows, []string{
+			runTask.Name,
+			runTask.ID,
+			runTask.Category,
+			strconv.FormatBool(runTask.Enabled),
+			strconv.Itoa(len(runTask.WorkspaceRunTasks)),
+			runTask.URL,
+		})
+	}
+	output.TableOutput([]string{"NAME", "ID", "CATEGORY", "ENABLED", "WORKSPACES", "URL"}, rows)
+
+	return nil
+}
+
+func listWorkspaceRunTasks(ctx context.Context, options *listOptions, runTasks []*tfe.RunTask) error {
+	c := options.TClient
+
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	var workspaceRunTasks []*tfe.WorkspaceRunTask
+	listOptions := &tfe.WorkspaceRunTaskListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		workspaceRunTaskList, err := c.WorkspaceRunTasks.List(ctx, workspace.ID, listOptions)
+		if err != nil {
+			return err
+		}
+		workspaceRunTasks = append(workspaceRunTasks, workspaceRunTaskList.Items...)
+
+		if workspaceRunTaskList.Pagination == nil || workspaceRunTaskList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = workspaceRunTaskList.NextPage
+	}
+
+	if options.Expand {
+		output.JsonOutput(workspaceRunTasks)
+		return nil
+	}
+
+	names := make(map[string]string, len(runTasks))
+	for _, runTask := range runTasks {
+		names[runTask.ID] = runTask.Name
+	}
+
+	rows := make([][]string, 0, len(workspaceRunTasks))
+	for _, workspaceRunTask := range workspaceRunTasks {
+		name := ""
+		if workspaceRunTask.RunTask != nil {
+			name = names[workspaceRunTask.RunTask.ID]
+		}
+		rows = append(rows, []string{name, workspaceRunTask.ID, joinStages(workspaceRunTask.Stages), string(workspaceRunTask.EnforcementLevel)})
+	}
+	output.TableOutput([]string{"TASK", "ID", "STAGES", "ENFORCEMENT"}, rows)
+
+	return nil
+}
+
+// listOrganizationRunTasks returns all the run tasks of an organization with their workspace attachments
+func listOrganizationRunTasks(ctx context.Context, c *tfe.Client, organization string) ([]*tfe.RunTask, error) {
+	var runTasks []*tfe.RunTask
+
+	listOptions := &tfe.RunTaskListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		Include:     []tfe.RunTaskIncludeOpt{tfe.RunTaskWorkspaceTasks},
+	}
+	for {
+		runTaskList, err := c.RunTasks.List(ctx, organization, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		runTasks = append(runTasks, runTaskList.Items...)
+
+		if runTaskList.Pagination == nil || runTaskList.NextPage == 0 {
+			return runTasks, nil
+		}
+		listOptions.PageNumber = runTaskList.NextPage
+	}
+}
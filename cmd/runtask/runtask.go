@@ -0,0 +1,116 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// Package describes the run task related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run-tasks/run-tasks
+
+type runTaskOptions struct {
+	*cmd.RootOptions
+}
+
+// NewRunTaskCmd create new run task command
+func NewRunTaskCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &runTaskOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:     "runtask",
+		Aliases: []string{"rt"},
+		Short:   "Work with terraform run tasks",
+		Long:    "Work with terraform run tasks and their workspace attachments, or serve a run task locally",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewRunTaskListCmd(options))
+	cobraCmd.AddCommand(NewRunTaskSaveCmd(options))
+	cobraCmd.AddCommand(NewRunTaskDeleteCmd(options))
+	cobraCmd.AddCommand(NewRunTaskAttachCmd(options))
+	cobraCmd.AddCommand(NewRunTaskDetachCmd(options))
+	cobraCmd.AddCommand(NewRunTaskServeCmd(options))
+
+	return cobraCmd
+}
+
+// findRunTaskID returns ID of the run task with given name within an organization, empty if it doesn't exist
+func findRunTaskID(ctx context.Context, c *tfe.Client, organization, runTaskName string) (string, error) {
+	listOptions := &tfe.RunTaskListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		runTaskList, err := c.RunTasks.List(ctx, organization, listOptions)
+		if err != nil {
+			return "", err
+		}
+
+		if runTaskID := utils.GetRunTaskID(runTaskList, runTaskName); runTaskID != "" {
+			return runTaskID, nil
+		}
+
+		if runTaskList.Pagination == nil || runTaskList.NextPage == 0 {
+			return "", nil
+		}
+		listOptions.PageNumber = runTaskList.NextPage
+	}
+}
+
+// readRunTaskID returns ID of the run task with given name within an organization
+func readRunTaskID(ctx context.Context, c *tfe.Client, organization, runTaskName string) (string, error) {
+	runTaskID, err := findRunTaskID(ctx, c, organization, runTaskName)
+	if err != nil {
+		return "", err
+	}
+	if runTaskID == "" {
+		return "", fmt.Errorf("run task '%s' not found in organization '%s'", runTaskName, organization)
+	}
+
+	return runTaskID, nil
+}
+
+// findWorkspaceRunTask returns attachment of the run task to the workspace, nil if the run task isn't attached
+func findWorkspaceRunTask(ctx context.Context, c *tfe.Client, workspaceID, runTaskID string) (*tfe.WorkspaceRunTask, error) {
+	listOptions := &tfe.WorkspaceRunTaskListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		workspaceRunTaskList, err := c.WorkspaceRunTasks.List(ctx, workspaceID, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workspaceRunTask := range workspaceRunTaskList.Items {
+			if workspaceRunTask.RunTask != nil && workspaceRunTask.RunTask.ID == runTaskID {
+				return workspaceRunTask, nil
+			}
+		}
+
+		if workspaceRunTaskList.Pagination == nil || workspaceRunTaskList.NextPage == 0 {
+			return nil, nil
+		}
+		listOptions.PageNumber = workspaceRunTaskList.NextPage
+	}
+}
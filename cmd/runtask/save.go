@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// saveOptions represents options for save command
+type saveOptions struct {
+	*runTaskOptions
+	runTaskName string
+	url         string
+	description string
+	category    string
+	hmacKey     string
+	enabled     bool
+}
+
+// NewRunTaskSaveCmd returns new run task save command
+func NewRunTaskSaveCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &saveOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "save",
+		Aliases: []string{"create"},
+		Short:   "save (create or update) a run task in terraform organization",
+		Long:    "save (create or update) a run task in terraform organization, the HMAC key is changed only when provided",
+		Example: "tfctl runtask save --name=... --url=https://scanner.example.com/hook [--hmac-key=...] [--description=...] [--enabled=false]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return saveRunTask(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.runTaskName, "name", "n", "", "terraform run task name")
+	cmd.Flags().StringVar(&options.url, "url", "", "URL receiving run task requests")
+	cmd.Flags().StringVarP(&options.description, "description", "d", "", "Optional: Run task description")
+	cmd.Flags().StringVar(&options.category, "category", "task", "Optional: Run task category")
+	cmd.Flags().StringVar(&options.hmacKey, "hmac-key", "", "Optional: Key used to sign run task requests")
+	cmd.Flags().BoolVar(&options.enabled, "enabled", true, "Optional: Whether the run task is enabled")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("url"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func saveRunTask(cmd *cobra.Command, options *saveOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	runTaskID, err := findRunTaskID(ctx, c, options.TerraformOrganization, options.runTaskName)
+	if err != nil {
+		return err
+	}
+
+	var hmacKey *string
+	if cmd.Flags().Changed("hmac-key") {
+		hmacKey = tfe.String(options.hmacKey)
+	}
+
+	var runTask *tfe.RunTask
+	if runTaskID == "" {
+		// Create a run task within an organization
+		runTask, err = c.RunTasks.Create(ctx, options.TerraformOrganization, tfe.RunTaskCreateOptions{
+			Name:        options.runTaskName,
+			URL:         options.url,
+			Description: tfe.String(options.description),
+			Category:    options.category,
+			HMACKey:     hmacKey,
+			Enabled:     tfe.Bool(options.enabled),
+		})
+	} else {
+		// Update an existing run task
+		runTask, err = c.RunTasks.Update(ctx, runTaskID, tfe.RunTaskUpdateOptions{
+			Name:        tfe.String(options.runTaskName),
+			URL:         tfe.String(options.url),
+			Description: tfe.String(options.description),
+			Category:    tfe.String(options.category),
+			HMACKey:     hmacKey,
+			Enabled:     tfe.Bool(options.enabled),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	output.JsonPrettyOutput(runTask, "runTask")
+
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/taskserver"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// serveOptions represents options for serve command
+type serveOptions struct {
+	*runTaskOptions
+	listen   string
+	path     string
+	hmacKey  string
+	timeout  time.Duration
+	dryRun   bool
+	insecure bool
+}
+
+// NewRunTaskServeCmd returns new run task serve command
+func NewRunTaskServeCmd(runTaskOptions *runTaskOptions) *cobra.Command {
+	options := &serveOptions{
+		runTaskOptions: runTaskOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "serve -- command [args...]",
+		Short: "serve a run task deciding pass or fail with a local command",
+		Long: "serve a run task: receive run task requests, verify their HMAC signature and run the local command for each of them. " +
+			"The request is passed to the command as JSON on stdin and as TFC_* environment variables " +
+			"(TFC_RUN_ID, TFC_WORKSPACE_NAME, TFC_STAGE, TFC_PLAN_JSON_API_URL, TFC_ACCESS_TOKEN, ...). " +
+			"Zero exit code passes the task, the last line of the command output is shown in the run",
+		Example: "tfctl runtask serve --listen=:8080 --hmac-key=... -- ./scan.sh --strict\n" +
+			"tfctl runtask serve --insecure --dry-run -- sh -c 'test \"$TFC_STAGE\" = post_plan'",
+		Args: cobra.MinimumNArgs(1),
+		// The server doesn't use Terraform API credentials, results are sent with the access token of each request
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serveRunTask(cmd, options, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.listen, "listen", "127.0.0.1:8080", "Optional: Address to listen on, e.g. ':8080' for all interfaces")
+	cmd.Flags().StringVar(&options.path, "path", "/", "Optional: URL path receiving run task requests")
+	cmd.Flags().StringVar(&options.hmacKey, "hmac-key", "",
+		"Key to verify request signatures (default from TFC_TASK_HMAC_KEY environment variable), required unless --insecure is set")
+	cmd.Flags().DurationVar(&options.timeout, "timeout", 10*time.Minute, "Optional: Maximum duration of the command")
+	cmd.Flags().BoolVar(&options.dryRun, "dry-run", false, "Optional: Only log results instead of sending them back to Terraform")
+	cmd.Flags().BoolVar(&options.insecure, "insecure", false,
+		"Optional: Accept requests without verifying their signatures, the command runs on any payload and results are sent to any callback URL")
+
+	return cmd
+}
+
+func serveRunTask(cmd *cobra.Command, options *serveOptions, args []string) error {
+	logger := log.New(cmd.ErrOrStderr(), "", log.LstdFlags)
+	// The key is read here so that it is never shown as the flag default in help
+	if options.hmacKey == "" {
+		options.hmacKey = os.Getenv("TFC_TASK_HMAC_KEY")
+	}
+	if options.hmacKey == "" {
+		if !options.insecure {
+			return fmt.Errorf("HMAC key is required to verify requests, set --hmac-key (or TFC_TASK_HMAC_KEY) or use --insecure")
+		}
+		logger.Printf("WARNING: HMAC key is not set, requests are not verified")
+	}
+
+	notify := func(ctx context.Context, request *tfe.RunTaskRequest, result taskserver.Result) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		return taskserver.Callback(ctx, http.DefaultClient, request, result)
+	}
+	if options.dryRun {
+		notify = func(_ context.Context, request *tfe.RunTaskRequest, result taskserver.Result) error {
+			logger.Printf("dry run: result of run '%s' is not sent to %s", request.RunID, request.TaskResultCallbackURL)
+			return nil
+		}
+	}
+
+	handler := taskserver.NewHandler(options.hmacKey, taskserver.CommandDecider(args, options.timeout), notify, logger.Printf)
+	mux := http.NewServeMux()
+	mux.Handle(options.path, handler)
+	server := &http.Server{
+		Addr:              options.listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		logger.Printf("serving run task on %s%s", options.listen, options.path)
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.Printf("shutting down, waiting for running tasks")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down server: %v", err)
+	}
+	handler.Wait()
+
+	return nil
+}
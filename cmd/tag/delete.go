@@ -0,0 +1,110 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// deleteOptions represents options for delete command
+type deleteOptions struct {
+	*tagOptions
+	tags []string
+	yes  bool
+}
+
+// NewTagDeleteCmd returns new tag delete command
+func NewTagDeleteCmd(tagOptions *tagOptions) *cobra.Command {
+	options := &deleteOptions{
+		tagOptions: tagOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"del", "rm"},
+		Short:   "delete tags from an organization and all its workspaces",
+		Long:    "delete tags from an organization and all its workspaces",
+		Example: "tfctl tags delete [--tag=...] [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return deleteTags(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&options.tags, "tag", "t", nil, "tag name to delete, can be repeated")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	if err := cmd.MarkFlagRequired("tag"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func deleteTags(cmd *cobra.Command, options *deleteOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// List all tags within an organization and filter tag IDs by provided names
+	tags, err := listOrganizationTags(ctx, c, options.TerraformOrganization, "")
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, name := range options.tags {
+		tag := findTag(tags, name)
+		if tag == nil {
+			return fmt.Errorf("tag '%s' not found in organization '%s'", name, options.TerraformOrganization)
+		}
+		fmt.Printf("Tag '%s' is used by %d workspace(s)\n", tag.Name, tag.InstanceCount)
+		ids = append(ids, tag.ID)
+	}
+
+	if !options.yes {
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Delete tag(s) "+strings.Join(options.tags, ", ")+"?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Deletion of tags canceled")
+			return nil
+		}
+	}
+
+	// Delete tags from an organization
+	if err := c.OrganizationTags.Delete(ctx, options.TerraformOrganization, tfe.OrganizationTagsDeleteOptions{IDs: ids}); err != nil {
+		return err
+	}
+	fmt.Println("Tag(s) '" + strings.Join(options.tags, ", ") + "' deleted successfully!")
+
+	return nil
+}
+
+func findTag(tags []*tfe.OrganizationTag, name string) *tfe.OrganizationTag {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag
+		}
+	}
+
+	return nil
+}
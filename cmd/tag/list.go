@@ -0,0 +1,117 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// listOptions represents options for list command
+type listOptions struct {
+	*tagOptions
+	query  string
+	unused bool
+}
+
+// NewTagListCmd returns new tag list command
+func NewTagListCmd(tagOptions *tagOptions) *cobra.Command {
+	options := &listOptions{
+		tagOptions: tagOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list all the tags within an organization with their usage counts",
+		Long:    "list all the tags within an organization with number of workspaces using them",
+		Example: "tfctl tags list [--query=...] [--unused]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listTags(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.query, "query", "q", "", "Optional: Show only tags with names like the query")
+	cmd.Flags().BoolVar(&options.unused, "unused", false, "Optional: Show only tags not used by any workspace")
+
+	return cmd
+}
+
+func listTags(_ *cobra.Command, options *listOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// List all tags within an organization
+	tags, err := listOrganizationTags(ctx, c, options.TerraformOrganization, options.query)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	if options.unused {
+		var unusedTags []*tfe.OrganizationTag
+		for _, tag := range tags {
+			if tag.InstanceCount == 0 {
+				unusedTags = append(unusedTags, tag)
+			}
+		}
+		tags = unusedTags
+	}
+
+	if options.Expand {
+		output.JsonOutput(tags)
+		return nil
+	}
+
+	var rows [][]string
+	for _, tag := range tags {
+		rows = append(rows, []string{tag.Name, strconv.Itoa(tag.InstanceCount)})
+	}
+	output.TableOutput([]string{"TAG", "WORKSPACES"}, rows)
+
+	return nil
+}
+
+// listOrganizationTags lists all tags within an organization going through all the pages
+func listOrganizationTags(ctx context.Context, c *tfe.Client, organization, query string) ([]*tfe.OrganizationTag, error) {
+	var tags []*tfe.OrganizationTag
+
+	listOptions := &tfe.OrganizationTagsListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		Query:       query,
+	}
+	for {
+		tagList, err := c.OrganizationTags.List(ctx, organization, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tagList.Items...)
+
+		if tagList.Pagination == nil || tagList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = tagList.NextPage
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tag
+
+import (
+	"github.com/ealebed/tfctl/cmd"
+
+	"github.com/spf13/cobra"
+)
+
+// Package describes the organization tag related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/organization-tags
+
+type tagOptions struct {
+	*cmd.RootOptions
+}
+
+// NewTagCmd create new tag command
+func NewTagCmd(rootOptions *cmd.RootOptions) *cobra.Command {
+	options := &tagOptions{
+		RootOptions: rootOptions,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:     "tags",
+		Aliases: []string{"tag"},
+		Short:   "Work with terraform organization tags",
+		Long:    "Work with terraform organization tags",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewTagListCmd(options))
+	cobraCmd.AddCommand(NewTagDeleteCmd(options))
+
+	return cobraCmd
+}
@@ -0,0 +1,397 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// accessOptions represents options for workspace team access commands
+type accessOptions struct {
+	*workspaceOptions
+	workspaceName    string
+	teamName         string
+	access           string
+	runs             string
+	variables        string
+	stateVersions    string
+	sentinelMocks    string
+	workspaceLocking bool
+	runTasks         bool
+}
+
+// customAccessFlags are flags allowed only with 'custom' access level
+var customAccessFlags = []string{"runs", "variables", "state-versions", "sentinel-mocks", "workspace-locking", "run-tasks"}
+
+// NewWorkspaceAccessCmd returns new workspace team access command
+func NewWorkspaceAccessCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "access",
+		Aliases: []string{"team-access"},
+		Short:   "manage team access to workspaces",
+		Long:    "manage team access to workspaces with built-in access levels (read, plan, write, admin) or custom permissions",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewWorkspaceAccessListCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceAccessGrantCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceAccessSetCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceAccessRevokeCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceAccessMatrixCmd(workspaceOptions))
+
+	return cobraCmd
+}
+
+// NewWorkspaceAccessListCmd returns new workspace access list command
+func NewWorkspaceAccessListCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &accessOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list all the teams having access to a workspace",
+		Long:    "list all the teams having access to a workspace with their permissions",
+		Example: "tfctl ws access list [--workspace=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listTeamAccess(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceAccessGrantCmd returns new workspace access grant command
+func NewWorkspaceAccessGrantCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceAccessSaveCmd(workspaceOptions, "grant", "grant a team access to a workspace", false)
+}
+
+// NewWorkspaceAccessSetCmd returns new workspace access set command
+func NewWorkspaceAccessSetCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceAccessSaveCmd(workspaceOptions, "set", "set (grant or update) team access to a workspace", true)
+}
+
+func newWorkspaceAccessSaveCmd(workspaceOptions *workspaceOptions, use, description string, update bool) *cobra.Command {
+	options := &accessOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   description,
+		Long:    description + ". Permissions flags are allowed only with 'custom' access level",
+		Example: "tfctl ws access " + use + " [--workspace=...] [--team=...] [--access=write|custom] [--runs=apply] [--variables=read]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return saveTeamAccess(cmd, options, update)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().StringVarP(&options.teamName, "team", "t", "", "terraform team name")
+	cmd.Flags().StringVarP(&options.access, "access", "a", "", "access level (read, plan, write, admin or custom)")
+	cmd.Flags().StringVar(&options.runs, "runs", "", "Optional: Custom runs permission (read, plan or apply)")
+	cmd.Flags().StringVar(&options.variables, "variables", "", "Optional: Custom variables permission (none, read or write)")
+	cmd.Flags().StringVar(&options.stateVersions, "state-versions", "",
+		"Optional: Custom state versions permission (none, read-outputs, read or write)")
+	cmd.Flags().StringVar(&options.sentinelMocks, "sentinel-mocks", "", "Optional: Custom Sentinel mocks permission (none or read)")
+	cmd.Flags().BoolVar(&options.workspaceLocking, "workspace-locking", false, "Optional: Custom permission to lock and unlock the workspace")
+	cmd.Flags().BoolVar(&options.runTasks, "run-tasks", false, "Optional: Custom permission to manage run tasks")
+	for _, flag := range []string{"workspace", "team", "access"} {
+		if err := cmd.MarkFlagRequired(flag); err != nil {
+			return nil
+		}
+	}
+
+	return cmd
+}
+
+// NewWorkspaceAccessRevokeCmd returns new workspace access revoke command
+func NewWorkspaceAccessRevokeCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &accessOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "revoke",
+		Aliases: []string{"rm"},
+		Short:   "revoke team access to a workspace",
+		Long:    "revoke team access to a workspace",
+		Example: "tfctl ws access revoke [--workspace=...] [--team=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return revokeTeamAccess(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().StringVarP(&options.teamName, "team", "t", "", "terraform team name")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("team"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func listTeamAccess(_ *cobra.Command, options *accessOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Check if workspace exists and got its ID
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// List all the team accesses for a given workspace
+	accesses, err := listWorkspaceTeamAccess(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	if options.Expand {
+		output.JsonOutput(accesses)
+		return nil
+	}
+
+	var rows [][]string
+	for _, access := range accesses {
+		team, err := c.Teams.Read(ctx, access.Team.ID)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []string{
+			team.Name,
+			string(access.Access),
+			string(access.Runs),
+			string(access.Variables),
+			string(access.StateVersions),
+			string(access.SentinelMocks),
+			strconv.FormatBool(access.WorkspaceLocking),
+			strconv.FormatBool(access.RunTasks),
+		})
+	}
+	output.TableOutput([]string{"TEAM", "ACCESS", "RUNS", "VARIABLES", "STATE-VERSIONS", "SENTINEL-MOCKS", "LOCKING", "RUN-TASKS"}, rows)
+
+	return nil
+}
+
+func saveTeamAccess(cmd *cobra.Command, options *accessOptions, update bool) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if err := validateTeamAccess(cmd, options); err != nil {
+		return err
+	}
+
+	workspace, teamID, existing, err := findTeamAccess(ctx, c, options)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && !update {
+		return fmt.Errorf("team '%s' already has '%s' access to workspace '%s', use 'tfctl ws access set' to change it",
+			options.teamName, existing.Access, options.workspaceName)
+	}
+
+	if existing != nil {
+		// Update team access of the workspace
+		updateOptions := tfe.TeamAccessUpdateOptions{Access: tfe.Access(tfe.AccessType(options.access))}
+		if options.access == string(tfe.AccessCustom) {
+			updateOptions.Runs, updateOptions.Variables, updateOptions.StateVersions, updateOptions.SentinelMocks,
+				updateOptions.WorkspaceLocking, updateOptions.RunTasks = customTeamAccess(cmd, options)
+		}
+		if _, err := c.TeamAccess.Update(ctx, existing.ID, updateOptions); err != nil {
+			return err
+		}
+	} else {
+		// Add team access for a workspace
+		addOptions := tfe.TeamAccessAddOptions{
+			Access:    tfe.Access(tfe.AccessType(options.access)),
+			Team:      &tfe.Team{ID: teamID},
+			Workspace: workspace,
+		}
+		if options.access == string(tfe.AccessCustom) {
+			addOptions.Runs, addOptions.Variables, addOptions.StateVersions, addOptions.SentinelMocks,
+				addOptions.WorkspaceLocking, addOptions.RunTasks = customTeamAccess(cmd, options)
+		}
+		if _, err := c.TeamAccess.Add(ctx, addOptions); err != nil {
+			return err
+		}
+	}
+	fmt.Println("Team '" + options.teamName + "' has '" + options.access + "' access to workspace '" + options.workspaceName + "'")
+
+	return nil
+}
+
+func revokeTeamAccess(_ *cobra.Command, options *accessOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	_, _, existing, err := findTeamAccess(ctx, c, options)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("team '%s' has no access to workspace '%s'", options.teamName, options.workspaceName)
+	}
+
+	// Remove team access from a workspace
+	if err := c.TeamAccess.Remove(ctx, existing.ID); err != nil {
+		return err
+	}
+	fmt.Println("Access of team '" + options.teamName + "' to workspace '" + options.workspaceName + "' revoked successfully!")
+
+	return nil
+}
+
+// validateTeamAccess checks access level and custom permissions values
+func validateTeamAccess(cmd *cobra.Command, options *accessOptions) error {
+	if err := utils.ValidateOneOf("access", options.access,
+		string(tfe.AccessRead), string(tfe.AccessPlan), string(tfe.AccessWrite), string(tfe.AccessAdmin), string(tfe.AccessCustom)); err != nil {
+		return err
+	}
+
+	if options.access != string(tfe.AccessCustom) {
+		for _, flag := range customAccessFlags {
+			if cmd.Flags().Changed(flag) {
+				return fmt.Errorf("flag --%s is allowed only with 'custom' access level", flag)
+			}
+		}
+		return nil
+	}
+
+	checks := []struct {
+		flag    string
+		value   string
+		allowed []string
+	}{
+		{"runs", options.runs, []string{"read", "plan", "apply"}},
+		{"variables", options.variables, []string{"none", "read", "write"}},
+		{"state-versions", options.stateVersions, []string{"none", "read-outputs", "read", "write"}},
+		{"sentinel-mocks", options.sentinelMocks, []string{"none", "read"}},
+	}
+	for _, check := range checks {
+		if !cmd.Flags().Changed(check.flag) {
+			continue
+		}
+		if err := utils.ValidateOneOf(check.flag+" permission", check.value, check.allowed...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customTeamAccess returns custom permissions set by flags, unset permissions are left to API defaults
+func customTeamAccess(cmd *cobra.Command, options *accessOptions) (*tfe.RunsPermissionType, *tfe.VariablesPermissionType,
+	*tfe.StateVersionsPermissionType, *tfe.SentinelMocksPermissionType, *bool, *bool) {
+	var runs *tfe.RunsPermissionType
+	var variables *tfe.VariablesPermissionType
+	var stateVersions *tfe.StateVersionsPermissionType
+	var sentinelMocks *tfe.SentinelMocksPermissionType
+	var workspaceLocking, runTasks *bool
+
+	if cmd.Flags().Changed("runs") {
+		runs = tfe.RunsPermission(tfe.RunsPermissionType(options.runs))
+	}
+	if cmd.Flags().Changed("variables") {
+		variables = tfe.VariablesPermission(tfe.VariablesPermissionType(options.variables))
+	}
+	if cmd.Flags().Changed("state-versions") {
+		stateVersions = tfe.StateVersionsPermission(tfe.StateVersionsPermissionType(options.stateVersions))
+	}
+	if cmd.Flags().Changed("sentinel-mocks") {
+		sentinelMocks = tfe.SentinelMocksPermission(tfe.SentinelMocksPermissionType(options.sentinelMocks))
+	}
+	if cmd.Flags().Changed("workspace-locking") {
+		workspaceLocking = tfe.Bool(options.workspaceLocking)
+	}
+	if cmd.Flags().Changed("run-tasks") {
+		runTasks = tfe.Bool(options.runTasks)
+	}
+
+	return runs, variables, stateVersions, sentinelMocks, workspaceLocking, runTasks
+}
+
+// findTeamAccess returns the workspace, team ID and existing team access to the workspace if any
+func findTeamAccess(ctx context.Context, c *tfe.Client, options *accessOptions) (*tfe.Workspace, string, *tfe.TeamAccess, error) {
+	// Check if workspace exists and got its ID
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// List teams with provided name and filter team ID
+	teamList, err := c.Teams.List(ctx, options.TerraformOrganization, &tfe.TeamListOptions{Names: []string{options.teamName}})
+	if err != nil {
+		return nil, "", nil, err
+	}
+	teamID := utils.GetTeamID(teamList, options.teamName)
+	if teamID == "" {
+		return nil, "", nil, fmt.Errorf("team '%s' not found in organization '%s'", options.teamName, options.TerraformOrganization)
+	}
+
+	// List all the team accesses for a given workspace
+	accesses, err := listWorkspaceTeamAccess(ctx, c, workspace.ID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	for _, access := range accesses {
+		if access.Team != nil && access.Team.ID == teamID {
+			return workspace, teamID, access, nil
+		}
+	}
+
+	return workspace, teamID, nil, nil
+}
+
+// listWorkspaceTeamAccess returns all the team accesses of the workspace
+func listWorkspaceTeamAccess(ctx context.Context, c *tfe.Client, workspaceID string) ([]*tfe.TeamAccess, error) {
+	var accesses []*tfe.TeamAccess
+
+	listOptions := &tfe.TeamAccessListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, WorkspaceID: workspaceID}
+	for {
+		teamAccessList, err := c.TeamAccess.List(ctx, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		accesses = append(accesses, teamAccessList.Items...)
+
+		if teamAccessList.Pagination == nil || teamAccessList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = teamAccessList.NextPage
+	}
+
+	return accesses, nil
+}
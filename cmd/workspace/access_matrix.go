@@ -0,0 +1,156 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// noAccess is shown in the access matrix for teams without access to a workspace
+const noAccess = "-"
+
+// matrixOptions represents options for access matrix command
+type matrixOptions struct {
+	*workspaceOptions
+	selector string
+	workers  int
+}
+
+// NewWorkspaceAccessMatrixCmd returns new workspace access matrix command
+func NewWorkspaceAccessMatrixCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &matrixOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "matrix",
+		Short:   "show team x workspace access matrix",
+		Long:    "show access levels of all the teams to all (or selected) workspaces within an organization",
+		Example: "tfctl ws access matrix [--selector=tag=prod]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showAccessMatrix(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"Optional: workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of workspaces processed concurrently")
+
+	return cmd
+}
+
+func showAccessMatrix(_ *cobra.Command, options *matrixOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// All the workspaces of the organization are used if selector is not set
+	sel, err := selector.ParseOptional(options.selector)
+	if err != nil {
+		return err
+	}
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+
+	teams, err := listOrganizationTeams(ctx, c, options.TerraformOrganization)
+	if err != nil {
+		return err
+	}
+
+	// matrix maps workspace name to team name to access level
+	matrix := make(map[string]map[string]string, len(workspaces))
+	var mu sync.Mutex
+	errs := worker.Run(ctx, workspaces, worker.Options{Workers: options.workers}, func(ctx context.Context, workspace *tfe.Workspace) error {
+		accesses, err := listWorkspaceTeamAccess(ctx, c, workspace.ID)
+		if err != nil {
+			return err
+		}
+		access := make(map[string]string, len(accesses))
+		for _, item := range accesses {
+			if item.Team != nil {
+				access[teams[item.Team.ID]] = string(item.Access)
+			}
+		}
+		mu.Lock()
+		matrix[workspace.Name] = access
+		mu.Unlock()
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Expand {
+		output.JsonOutput(matrix)
+		return nil
+	}
+
+	teamNames := make([]string, 0, len(teams))
+	for _, name := range teams {
+		teamNames = append(teamNames, name)
+	}
+	sort.Strings(teamNames)
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Name < workspaces[j].Name })
+
+	rows := make([][]string, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		row := []string{workspace.Name}
+		for _, team := range teamNames {
+			level, ok := matrix[workspace.Name][team]
+			if !ok {
+				level = noAccess
+			}
+			row = append(row, level)
+		}
+		rows = append(rows, row)
+	}
+	output.TableOutput(append([]string{"WORKSPACE"}, teamNames...), rows)
+
+	return nil
+}
+
+// listOrganizationTeams returns names of all the teams within an organization mapped by team ID
+func listOrganizationTeams(ctx context.Context, c *tfe.Client, organization string) (map[string]string, error) {
+	teams := make(map[string]string)
+	listOptions := &tfe.TeamListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		teamList, err := c.Teams.List(ctx, organization, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, team := range teamList.Items {
+			teams[team.ID] = team.Name
+		}
+		if teamList.Pagination == nil || teamList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = teamList.NextPage
+	}
+
+	return teams, nil
+}
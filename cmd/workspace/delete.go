@@ -17,8 +17,13 @@ package workspace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/ealebed/tfctl/pkg/runstate"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +31,9 @@ import (
 type deleteOptions struct {
 	*workspaceOptions
 	workspaceName string
+	yes           bool
+	force         bool
+	destroyFirst  bool
 }
 
 // NewWorkspaceDeleteCmd returns new workspace delete command
@@ -38,14 +46,19 @@ func NewWorkspaceDeleteCmd(workspaceOptions *workspaceOptions) *cobra.Command {
 		Use:     "delete",
 		Aliases: []string{"del", "rm"},
 		Short:   "delete a terraform workspace by its name",
-		Long:    "delete a terraform workspace by its name",
-		Example: "tfctl ws delete [--workspace=...]",
+		Long: "delete a terraform workspace by its name. By default the workspace is deleted only " +
+			"if it doesn't manage any resources (safe delete)",
+		Example: "tfctl ws delete [--workspace=...] [--yes] [--destroy-first] [--force]",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return deleteWorkspace(cmd, options)
 		},
 	}
 
 	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "name terraform workspace to delete")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	cmd.Flags().BoolVar(&options.force, "force", false, "Optional: Delete the workspace even if it still manages resources")
+	cmd.Flags().BoolVar(&options.destroyFirst, "destroy-first", false,
+		"Optional: Queue a destroy run, wait until it is applied and delete the workspace afterwards")
 	if err := cmd.MarkFlagRequired("workspace"); err != nil {
 		return nil
 	}
@@ -53,15 +66,92 @@ func NewWorkspaceDeleteCmd(workspaceOptions *workspaceOptions) *cobra.Command {
 	return cmd
 }
 
-func deleteWorkspace(_ *cobra.Command, options *deleteOptions) error {
+func deleteWorkspace(cmd *cobra.Command, options *deleteOptions) error {
 	c := options.TClient
 	ctx := context.Background()
 
-	// Delete a workspace by its name
-	if err := c.Workspaces.Delete(ctx, options.TerraformOrganization, options.workspaceName); err != nil {
+	// Read a workspace with its current run to show what is going to be deleted
+	workspace, err := c.Workspaces.ReadWithOptions(ctx, options.TerraformOrganization, options.workspaceName, &tfe.WorkspaceReadOptions{
+		Include: []tfe.WSIncludeOpt{tfe.WSCurrentRun},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Workspace '%s' (%s) manages %d resource(s)\n", workspace.Name, workspace.ID, workspace.ResourceCount)
+	if workspace.CurrentRun != nil {
+		fmt.Printf("Last run: %s, status '%s', created at %s\n",
+			workspace.CurrentRun.ID, workspace.CurrentRun.Status, workspace.CurrentRun.CreatedAt.Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Println("Last run: none")
+	}
+
+	if !options.yes {
+		question := "Delete workspace '" + workspace.Name + "'?"
+		if options.destroyFirst {
+			question = "Destroy all resources managed by workspace '" + workspace.Name + "' and delete it?"
+		}
+
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Deletion of workspace '" + workspace.Name + "' canceled")
+			return nil
+		}
+	}
+
+	if options.destroyFirst && workspace.ResourceCount > 0 {
+		if err := destroyWorkspaceResources(ctx, c, workspace); err != nil {
+			return err
+		}
+	}
+
+	if options.force {
+		// Delete a workspace by its ID regardless of managed resources
+		err = c.Workspaces.DeleteByID(ctx, workspace.ID)
+	} else {
+		// Delete a workspace by its ID only if it doesn't manage any resources
+		err = c.Workspaces.SafeDeleteByID(ctx, workspace.ID)
+	}
+	if err != nil {
+		if errors.Is(err, tfe.ErrWorkspaceNotSafeToDelete) {
+			return fmt.Errorf("%w\nuse --destroy-first to destroy resources before deletion or --force to delete anyway", err)
+		}
 		return err
 	}
 	fmt.Println("Workspace '" + options.workspaceName + "' deleted successfully!")
 
 	return nil
 }
+
+// destroyWorkspaceResources queues an auto-applied destroy run and waits until it is finished
+func destroyWorkspaceResources(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace) error {
+	run, err := c.Runs.Create(ctx, tfe.RunCreateOptions{
+		Workspace: workspace,
+		IsDestroy: tfe.Bool(true),
+		AutoApply: tfe.Bool(true),
+		Message:   tfe.String("Destroy resources before deleting workspace by tfctl"),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Destroy run '" + run.ID + "' queued, waiting for it to be applied...")
+
+	run, err = runstate.Wait(ctx, c, run.ID, runstate.WaitOptions{
+		OnStatus: func(r *tfe.Run) {
+			fmt.Println("Run '" + r.ID + "' status: " + string(r.Status))
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	switch run.Status {
+	case tfe.RunApplied, tfe.RunPlannedAndFinished:
+		return nil
+	default:
+		return fmt.Errorf("destroy run '%s' finished with status '%s', workspace '%s' is not deleted", run.ID, run.Status, workspace.Name)
+	}
+}
@@ -0,0 +1,401 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ealebed/tfctl/pkg/graph"
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// describeOptions represents options for describe command
+type describeOptions struct {
+	*workspaceOptions
+	workspaceName string
+	outputFormat  string
+}
+
+// workspaceDescription represents aggregated view of a workspace
+type workspaceDescription struct {
+	ID               string                   `json:"id"`
+	Name             string                   `json:"name"`
+	Description      string                   `json:"description"`
+	Project          string                   `json:"project,omitempty"`
+	ExecutionMode    string                   `json:"execution-mode"`
+	TerraformVersion string                   `json:"terraform-version"`
+	WorkingDirectory string                   `json:"working-directory"`
+	AutoApply        bool                     `json:"auto-apply"`
+	VCSRepo          string                   `json:"vcs-repo,omitempty"`
+	TagNames         []string                 `json:"tag-names"`
+	ResourceCount    int                      `json:"resource-count"`
+	Locked           bool                     `json:"locked"`
+	LockedBy         string                   `json:"locked-by,omitempty"`
+	CurrentRun       *describedRun            `json:"current-run,omitempty"`
+	Outputs          []*describedOutput       `json:"outputs"`
+	Variables        []*describedVariable     `json:"variables"`
+	PolicySets       []string                 `json:"policy-sets"`
+	TeamAccess       []*describedTeamAccess   `json:"team-access"`
+	RunTriggers      []string                 `json:"run-triggers"`
+	Notifications    []*describedNotification `json:"notifications"`
+}
+
+type describedRun struct {
+	ID        string        `json:"id"`
+	Status    tfe.RunStatus `json:"status"`
+	Message   string        `json:"message"`
+	CreatedAt time.Time     `json:"created-at"`
+}
+
+type describedOutput struct {
+	Name      string      `json:"name"`
+	Type      string      `json:"type"`
+	Sensitive bool        `json:"sensitive"`
+	Value     interface{} `json:"value,omitempty"`
+}
+
+type describedVariable struct {
+	Key       string           `json:"key"`
+	Category  tfe.CategoryType `json:"category"`
+	Sensitive bool             `json:"sensitive"`
+}
+
+type describedTeamAccess struct {
+	Team   string         `json:"team"`
+	Access tfe.AccessType `json:"access"`
+}
+
+type describedNotification struct {
+	Name            string                          `json:"name"`
+	DestinationType tfe.NotificationDestinationType `json:"destination-type"`
+	Enabled         bool                            `json:"enabled"`
+	Triggers        []string                        `json:"triggers"`
+}
+
+// NewWorkspaceDescribeCmd returns new workspace describe command
+func NewWorkspaceDescribeCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &describeOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "show aggregated information about a workspace",
+		Long: "show workspace settings, lock status, current run, latest state outputs, variables, " +
+			"attached policy sets, team access, run triggers and notification configurations",
+		Example: "tfctl ws describe [--workspace=...] [--output=json]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return describeWorkspace(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name to describe")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", "text", "Optional: Output format (text or json)")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func describeWorkspace(_ *cobra.Command, options *describeOptions) error {
+	if options.outputFormat != "text" && options.outputFormat != "json" {
+		return fmt.Errorf("unsupported output format '%s', use 'text' or 'json'", options.outputFormat)
+	}
+
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a workspace with its project, current run and lock holder
+	workspace, err := c.Workspaces.ReadWithOptions(ctx, options.TerraformOrganization, options.workspaceName, &tfe.WorkspaceReadOptions{
+		Include: []tfe.WSIncludeOpt{tfe.WSProject, tfe.WSCurrentRun, tfe.WSLockedBy},
+	})
+	if err != nil {
+		return err
+	}
+
+	description := newWorkspaceDescription(workspace)
+
+	collectors := []func(context.Context, *tfe.Client, *tfe.Workspace, *workspaceDescription) error{
+		describeOutputs,
+		describeVariables,
+		describePolicySets,
+		describeTeamAccess,
+		describeRunTriggers,
+		describeNotifications,
+	}
+	for _, collect := range collectors {
+		if err := collect(ctx, c, workspace, description); err != nil {
+			return err
+		}
+	}
+
+	if options.outputFormat == "json" {
+		output.JsonOutput(description)
+	} else {
+		printWorkspaceDescription(description)
+	}
+
+	return nil
+}
+
+func newWorkspaceDescription(workspace *tfe.Workspace) *workspaceDescription {
+	description := &workspaceDescription{
+		ID:               workspace.ID,
+		Name:             workspace.Name,
+		Description:      workspace.Description,
+		ExecutionMode:    workspace.ExecutionMode,
+		TerraformVersion: workspace.TerraformVersion,
+		WorkingDirectory: workspace.WorkingDirectory,
+		AutoApply:        workspace.AutoApply,
+		TagNames:         workspace.TagNames,
+		ResourceCount:    workspace.ResourceCount,
+		Locked:           workspace.Locked,
+	}
+
+	if workspace.Project != nil {
+		description.Project = workspace.Project.Name
+	}
+	if workspace.VCSRepo != nil {
+		description.VCSRepo = workspace.VCSRepo.Identifier
+	}
+	if workspace.CurrentRun != nil {
+		description.CurrentRun = &describedRun{
+			ID:        workspace.CurrentRun.ID,
+			Status:    workspace.CurrentRun.Status,
+			Message:   workspace.CurrentRun.Message,
+			CreatedAt: workspace.CurrentRun.CreatedAt,
+		}
+	}
+	if lockedBy := workspace.LockedBy; lockedBy != nil {
+		switch {
+		case lockedBy.Run != nil:
+			description.LockedBy = "run " + lockedBy.Run.ID
+		case lockedBy.User != nil:
+			description.LockedBy = "user " + lockedBy.User.Username
+		case lockedBy.Team != nil:
+			description.LockedBy = "team " + lockedBy.Team.Name
+		}
+	}
+
+	return description
+}
+
+func describeOutputs(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	outputs, err := readCurrentOutputs(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range outputs {
+		described := &describedOutput{Name: o.Name, Type: o.Type, Sensitive: o.Sensitive}
+		if !o.Sensitive {
+			described.Value = o.Value
+		}
+		description.Outputs = append(description.Outputs, described)
+	}
+
+	return nil
+}
+
+func describeVariables(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	variables, err := listWorkspaceVariables(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range variables {
+		description.Variables = append(description.Variables, &describedVariable{Key: v.Key, Category: v.Category, Sensitive: v.Sensitive})
+	}
+
+	return nil
+}
+
+func describePolicySets(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	listOptions := &tfe.PolicySetListOptions{
+		ListOptions: tfe.ListOptions{PageSize: 100},
+		Include:     []tfe.PolicySetIncludeOpt{tfe.PolicySetWorkspaces, tfe.PolicySetProjects},
+	}
+
+	for {
+		policySetList, err := c.PolicySets.List(ctx, workspace.Organization.Name, listOptions)
+		if err != nil {
+			return err
+		}
+
+		for _, policySet := range policySetList.Items {
+			if policySetApplies(policySet, workspace) {
+				description.PolicySets = append(description.PolicySets, policySet.Name)
+			}
+		}
+
+		if policySetList.Pagination == nil || policySetList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = policySetList.NextPage
+	}
+
+	return nil
+}
+
+// policySetApplies returns true if the policy set is global or attached to the workspace or its project
+func policySetApplies(policySet *tfe.PolicySet, workspace *tfe.Workspace) bool {
+	if policySet.Global {
+		return true
+	}
+	for _, w := range policySet.Workspaces {
+		if w.ID == workspace.ID {
+			return true
+		}
+	}
+	for _, p := range policySet.Projects {
+		if workspace.Project != nil && p.ID == workspace.Project.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describeTeamAccess(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	accesses, err := listWorkspaceTeamAccess(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, access := range accesses {
+		team, err := c.Teams.Read(ctx, access.Team.ID)
+		if err != nil {
+			return err
+		}
+		description.TeamAccess = append(description.TeamAccess, &describedTeamAccess{Team: team.Name, Access: access.Access})
+	}
+
+	return nil
+}
+
+func describeRunTriggers(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	runTriggers, err := graph.ListRunTriggers(ctx, c, workspace.ID, tfe.RunTriggerInbound)
+	if err != nil {
+		return err
+	}
+
+	for _, trigger := range runTriggers {
+		description.RunTriggers = append(description.RunTriggers, trigger.SourceableName)
+	}
+
+	return nil
+}
+
+func describeNotifications(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, description *workspaceDescription) error {
+	listOptions := &tfe.NotificationConfigurationListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		notificationList, err := c.NotificationConfigurations.List(ctx, workspace.ID, listOptions)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range notificationList.Items {
+			description.Notifications = append(description.Notifications, &describedNotification{
+				Name:            n.Name,
+				DestinationType: n.DestinationType,
+				Enabled:         n.Enabled,
+				Triggers:        n.Triggers,
+			})
+		}
+
+		if notificationList.Pagination == nil || notificationList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = notificationList.NextPage
+	}
+
+	return nil
+}
+
+func printWorkspaceDescription(d *workspaceDescription) {
+	fmt.Printf("Name:               %s (%s)\n", d.Name, d.ID)
+	fmt.Printf("Description:        %s\n", d.Description)
+	fmt.Printf("Project:            %s\n", d.Project)
+	fmt.Printf("Execution mode:     %s\n", d.ExecutionMode)
+	fmt.Printf("Terraform version:  %s\n", d.TerraformVersion)
+	fmt.Printf("Working directory:  %s\n", d.WorkingDirectory)
+	fmt.Printf("Auto apply:         %t\n", d.AutoApply)
+	fmt.Printf("VCS repository:     %s\n", d.VCSRepo)
+	fmt.Printf("Tags:               %s\n", strings.Join(d.TagNames, ", "))
+	fmt.Printf("Resources:          %d\n", d.ResourceCount)
+	if d.Locked {
+		fmt.Printf("Locked:             yes, by %s\n", d.LockedBy)
+	} else {
+		fmt.Println("Locked:             no")
+	}
+	if d.CurrentRun != nil {
+		fmt.Printf("Current run:        %s, status '%s', created at %s\n",
+			d.CurrentRun.ID, d.CurrentRun.Status, d.CurrentRun.CreatedAt.Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Println("Current run:        none")
+	}
+
+	fmt.Println("\nOutputs:")
+	for _, o := range d.Outputs {
+		value := output.RedactedValue
+		if !o.Sensitive {
+			raw, _ := json.Marshal(o.Value)
+			value = string(raw)
+		}
+		fmt.Printf("  %s = %s\n", o.Name, value)
+	}
+
+	fmt.Println("\nVariables:")
+	for _, v := range d.Variables {
+		sensitive := ""
+		if v.Sensitive {
+			sensitive = ", sensitive"
+		}
+		fmt.Printf("  %s (%s%s)\n", v.Key, v.Category, sensitive)
+	}
+
+	printDescriptionList("Policy sets", d.PolicySets)
+
+	fmt.Println("\nTeam access:")
+	for _, a := range d.TeamAccess {
+		fmt.Printf("  %s: %s\n", a.Team, a.Access)
+	}
+
+	printDescriptionList("Run triggers (source workspaces)", d.RunTriggers)
+
+	fmt.Println("\nNotifications:")
+	for _, n := range d.Notifications {
+		fmt.Printf("  %s (%s, enabled: %t): %s\n", n.Name, n.DestinationType, n.Enabled, strings.Join(n.Triggers, ", "))
+	}
+}
+
+func printDescriptionList(title string, items []string) {
+	sort.Strings(items)
+
+	fmt.Printf("\n%s:\n", title)
+	for _, item := range items {
+		fmt.Printf("  %s\n", item)
+	}
+}
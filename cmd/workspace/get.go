@@ -20,6 +20,7 @@ import (
 
 	"github.com/ealebed/tfctl/pkg/output"
 
+	"github.com/hashicorp/go-tfe"
 	"github.com/spf13/cobra"
 )
 
@@ -58,8 +59,10 @@ func getWorkspace(_ *cobra.Command, options *getOptions) error {
 	c := options.TClient
 	ctx := context.Background()
 
-	// Read a workspace by its name and organization name
-	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	// Read a workspace by its name and organization name including its project
+	workspace, err := c.Workspaces.ReadWithOptions(ctx, options.TerraformOrganization, options.workspaceName, &tfe.WorkspaceReadOptions{
+		Include: []tfe.WSIncludeOpt{tfe.WSProject},
+	})
 	if err != nil {
 		return err
 	}
@@ -54,7 +54,9 @@ func listWorkspaces(_ *cobra.Command, options *listOptions) error {
 	ctx := context.Background()
 
 	// List all the workspaces within an organization
-	workspaceList, err := c.Workspaces.List(ctx, options.TerraformOrganization, &tfe.WorkspaceListOptions{})
+	workspaceList, err := c.Workspaces.List(ctx, options.TerraformOrganization, &tfe.WorkspaceListOptions{
+		Include: []tfe.WSIncludeOpt{tfe.WSProject},
+	})
 	if err != nil {
 		return err
 	}
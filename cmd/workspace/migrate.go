@@ -0,0 +1,404 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is required by the state versions API
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ealebed/tfctl/cmd"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// migrateOptions represents options for migrate command
+type migrateOptions struct {
+	*workspaceOptions
+	workspaceName       string
+	targetWorkspaceName string
+	toHost              string
+	toOrganization      string
+	toToken             string
+	sensitiveValues     string
+	skipState           bool
+	keepOnFailure       bool
+}
+
+// NewWorkspaceMigrateCmd returns new workspace migrate command
+func NewWorkspaceMigrateCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &migrateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "migrate a workspace with its state to another organization or host",
+		Long: "migrate a workspace to another organization or host: recreate the workspace with its settings, " +
+			"variables, tags and team access, then copy the latest state version and verify its serial and lineage. " +
+			"VCS connection, agent pool and run history are not migrated",
+		Example: "tfctl ws migrate [--workspace=...] [--to-host=...] [--to-org=...] [--sensitive-values=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return migrateWorkspace(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name to migrate")
+	cmd.Flags().StringVar(&options.targetWorkspaceName, "to-workspace", "", "Optional: Target workspace name, the same as source by default")
+	cmd.Flags().StringVar(&options.toHost, "to-host", "app.terraform.io", "Target Terraform Enterprise (Cloud) host")
+	cmd.Flags().StringVar(&options.toOrganization, "to-org", "", "Target Terraform Enterprise (Cloud) organization name")
+	cmd.Flags().StringVar(&options.toToken, "to-token", "",
+		"Optional: Target Terraform Enterprise (Cloud) token, obtained from credentials file by default")
+	cmd.Flags().StringVar(&options.sensitiveValues, "sensitive-values", "",
+		"Optional: JSON file with values of sensitive variables, e.g. '{\"db_password\": \"...\"}'")
+	cmd.Flags().BoolVar(&options.skipState, "skip-state", false, "Optional: Don't migrate the latest state version")
+	cmd.Flags().BoolVar(&options.keepOnFailure, "keep-on-failure", false,
+		"Optional: Keep partially migrated target workspace if migration fails, it is deleted by default")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("to-org"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func migrateWorkspace(_ *cobra.Command, options *migrateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	if options.targetWorkspaceName == "" {
+		options.targetWorkspaceName = options.workspaceName
+	}
+
+	target, err := cmd.NewClient(options.toHost, options.toToken)
+	if err != nil {
+		return fmt.Errorf("failed to create client for host '%s': %v", options.toHost, err)
+	}
+
+	// Read a source workspace by its name and organization name
+	source, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// Check all the sensitive values are provided before anything is created
+	variables, err := listWorkspaceVariables(ctx, c, source.ID)
+	if err != nil {
+		return err
+	}
+	sensitiveValues, err := readSensitiveValues(options.sensitiveValues, variables)
+	if err != nil {
+		return err
+	}
+
+	// Check target workspace doesn't exist yet
+	if _, err := target.Workspaces.Read(ctx, options.toOrganization, options.targetWorkspaceName); err == nil {
+		return fmt.Errorf("workspace '%s' already exists in organization '%s' on host '%s'",
+			options.targetWorkspaceName, options.toOrganization, options.toHost)
+	} else if !errors.Is(err, tfe.ErrResourceNotFound) {
+		return err
+	}
+
+	workspace, err := createMigratedWorkspace(ctx, c, target, source, options)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Workspace '" + workspace.Name + "' created in organization '" + options.toOrganization + "'")
+
+	if err := configureMigratedWorkspace(ctx, c, target, source, workspace, variables, sensitiveValues, options); err != nil {
+		return rollbackMigratedWorkspace(ctx, target, workspace, options, err)
+	}
+
+	fmt.Println("Workspace '" + options.workspaceName + "' migrated to '" + options.toHost + "/" +
+		options.toOrganization + "/" + workspace.Name + "' successfully!")
+
+	return nil
+}
+
+// configureMigratedWorkspace copies variables, team access and state (unless skipped) to the created target workspace
+func configureMigratedWorkspace(ctx context.Context, c, target *tfe.Client, source, workspace *tfe.Workspace,
+	variables []*tfe.Variable, sensitiveValues map[string]string, options *migrateOptions,
+) error {
+	if err := migrateVariables(ctx, target, workspace, variables, sensitiveValues); err != nil {
+		return fmt.Errorf("failed to migrate variables: %w", err)
+	}
+
+	if err := migrateTeamAccess(ctx, c, target, source, workspace, options.toOrganization); err != nil {
+		return fmt.Errorf("failed to migrate team access: %w", err)
+	}
+
+	if !options.skipState {
+		if err := migrateState(ctx, c, target, source, workspace); err != nil {
+			return fmt.Errorf("failed to migrate state: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// rollbackMigratedWorkspace deletes partially migrated target workspace (unless it should be kept),
+// so the migration can be repeated, and returns the migration error
+func rollbackMigratedWorkspace(ctx context.Context, target *tfe.Client, workspace *tfe.Workspace, options *migrateOptions, migrateErr error) error {
+	if options.keepOnFailure {
+		fmt.Println("WARNING: workspace '" + workspace.Name + "' in organization '" + options.toOrganization +
+			"' is partially migrated, delete it before repeating the migration")
+		return migrateErr
+	}
+
+	if err := target.Workspaces.DeleteByID(ctx, workspace.ID); err != nil {
+		return fmt.Errorf("%w; failed to delete partially migrated workspace '%s', delete it before repeating the migration: %v",
+			migrateErr, workspace.Name, err)
+	}
+	fmt.Println("Partially migrated workspace '" + workspace.Name + "' deleted from organization '" + options.toOrganization + "'")
+
+	return migrateErr
+}
+
+// readSensitiveValues reads values file and checks it contains values for all the sensitive variables
+func readSensitiveValues(path string, variables []*tfe.Variable) (map[string]string, error) {
+	values := map[string]string{}
+	if path != "" {
+		var err error
+		if values, err = utils.ReadVariableValues(path); err != nil {
+			return nil, err
+		}
+	}
+
+	var missing []string
+	for _, v := range variables {
+		if _, ok := values[v.Key]; v.Sensitive && !ok {
+			missing = append(missing, v.Key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("values of sensitive variable(s) %s are missing, provide them with --sensitive-values",
+			strings.Join(missing, ", "))
+	}
+
+	return values, nil
+}
+
+// createMigratedWorkspace creates target workspace with settings, tags and tag bindings of the source one
+func createMigratedWorkspace(ctx context.Context, c, target *tfe.Client, source *tfe.Workspace,
+	options *migrateOptions) (*tfe.Workspace, error) {
+	createOptions := tfe.WorkspaceCreateOptions{
+		Name:                tfe.String(options.targetWorkspaceName),
+		Description:         tfe.String(source.Description),
+		AllowDestroyPlan:    tfe.Bool(source.AllowDestroyPlan),
+		AutoApply:           tfe.Bool(source.AutoApply),
+		AutoApplyRunTrigger: tfe.Bool(source.AutoApplyRunTrigger),
+		FileTriggersEnabled: tfe.Bool(source.FileTriggersEnabled),
+		GlobalRemoteState:   tfe.Bool(source.GlobalRemoteState),
+		QueueAllRuns:        tfe.Bool(source.QueueAllRuns),
+		SpeculativeEnabled:  tfe.Bool(source.SpeculativeEnabled),
+		TerraformVersion:    tfe.String(source.TerraformVersion),
+		TriggerPrefixes:     source.TriggerPrefixes,
+		TriggerPatterns:     source.TriggerPatterns,
+		WorkingDirectory:    tfe.String(source.WorkingDirectory),
+	}
+
+	// Agent pools belong to the source organization, so such workspaces fall back to remote execution
+	if source.ExecutionMode == "agent" {
+		fmt.Println("WARNING: workspace '" + source.Name + "' uses agent execution mode, target workspace will use remote execution")
+		createOptions.ExecutionMode = tfe.String("remote")
+	} else {
+		createOptions.ExecutionMode = tfe.String(source.ExecutionMode)
+	}
+
+	for _, tag := range source.TagNames {
+		createOptions.Tags = append(createOptions.Tags, &tfe.Tag{Name: tag})
+	}
+
+	tagBindings, err := c.Workspaces.ListTagBindings(ctx, source.ID)
+	if err != nil {
+		fmt.Println("WARNING: failed to read tag bindings of workspace '" + source.Name + "', they are not migrated: " + err.Error())
+	}
+	for _, binding := range tagBindings {
+		createOptions.TagBindings = append(createOptions.TagBindings, &tfe.TagBinding{Key: binding.Key, Value: binding.Value})
+	}
+
+	return target.Workspaces.Create(ctx, options.toOrganization, createOptions)
+}
+
+// migrateVariables creates source workspace variables in the target one
+func migrateVariables(ctx context.Context, target *tfe.Client, workspace *tfe.Workspace,
+	variables []*tfe.Variable, sensitiveValues map[string]string) error {
+	for _, v := range variables {
+		value := v.Value
+		if v.Sensitive {
+			value = sensitiveValues[v.Key]
+		}
+
+		_, err := target.Variables.Create(ctx, workspace.ID, tfe.VariableCreateOptions{
+			Key:         tfe.String(v.Key),
+			Value:       tfe.String(value),
+			Description: tfe.String(v.Description),
+			Category:    tfe.Category(v.Category),
+			HCL:         tfe.Bool(v.HCL),
+			Sensitive:   tfe.Bool(v.Sensitive),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create variable '%s': %v", v.Key, err)
+		}
+	}
+	fmt.Printf("%d variable(s) migrated\n", len(variables))
+
+	return nil
+}
+
+// migrateTeamAccess grants the same access to the target workspace for teams with the same names in target organization
+func migrateTeamAccess(ctx context.Context, c, target *tfe.Client, source, workspace *tfe.Workspace, toOrganization string) error {
+	accesses, err := listWorkspaceTeamAccess(ctx, c, source.ID)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, access := range accesses {
+		team, err := c.Teams.Read(ctx, access.Team.ID)
+		if err != nil {
+			return err
+		}
+
+		targetTeams, err := target.Teams.List(ctx, toOrganization, &tfe.TeamListOptions{Names: []string{team.Name}})
+		if err != nil {
+			return err
+		}
+		if len(targetTeams.Items) == 0 {
+			fmt.Println("WARNING: team '" + team.Name + "' doesn't exist in organization '" + toOrganization + "', access is not migrated")
+			continue
+		}
+
+		addOptions := tfe.TeamAccessAddOptions{
+			Access:    tfe.Access(access.Access),
+			Team:      targetTeams.Items[0],
+			Workspace: workspace,
+		}
+		if access.Access == tfe.AccessCustom {
+			addOptions.Runs = tfe.RunsPermission(access.Runs)
+			addOptions.Variables = tfe.VariablesPermission(access.Variables)
+			addOptions.StateVersions = tfe.StateVersionsPermission(access.StateVersions)
+			addOptions.SentinelMocks = tfe.SentinelMocksPermission(access.SentinelMocks)
+			addOptions.WorkspaceLocking = tfe.Bool(access.WorkspaceLocking)
+			addOptions.RunTasks = tfe.Bool(access.RunTasks)
+		}
+
+		if _, err := target.TeamAccess.Add(ctx, addOptions); err != nil {
+			return fmt.Errorf("failed to grant access to team '%s': %v", team.Name, err)
+		}
+		migrated++
+	}
+	fmt.Printf("%d team access(es) migrated\n", migrated)
+
+	return nil
+}
+
+// migrateState uploads the latest source state version to the target workspace and verifies its serial and lineage
+func migrateState(ctx context.Context, c, target *tfe.Client, source, workspace *tfe.Workspace) error {
+	stateVersion, err := c.StateVersions.ReadCurrent(ctx, source.ID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		fmt.Println("Workspace '" + source.Name + "' has no state, nothing to migrate")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	state, err := c.StateVersions.Download(ctx, stateVersion.DownloadURL)
+	if err != nil {
+		return err
+	}
+	meta, err := utils.ParseStateMeta(state)
+	if err != nil {
+		return err
+	}
+
+	// State versions can be created only in a locked workspace
+	if _, err := target.Workspaces.Lock(ctx, workspace.ID, tfe.WorkspaceLockOptions{
+		Reason: tfe.String("Migrating state by tfctl"),
+	}); err != nil {
+		return err
+	}
+
+	_, err = target.StateVersions.Create(ctx, workspace.ID, tfe.StateVersionCreateOptions{
+		Lineage: tfe.String(meta.Lineage),
+		MD5:     tfe.String(fmt.Sprintf("%x", md5.Sum(state))), // #nosec G401 -- MD5 is required by the state versions API
+		Serial:  tfe.Int64(meta.Serial),
+		State:   tfe.String(base64.StdEncoding.EncodeToString(state)),
+	})
+	if _, unlockErr := target.Workspaces.Unlock(ctx, workspace.ID); unlockErr != nil && err == nil {
+		err = unlockErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload state: %v", err)
+	}
+
+	return verifyMigratedState(ctx, target, workspace, meta)
+}
+
+// verifyMigratedState checks the current state of the target workspace has expected serial and lineage
+func verifyMigratedState(ctx context.Context, target *tfe.Client, workspace *tfe.Workspace, expected *utils.StateMeta) error {
+	stateVersion, err := target.StateVersions.ReadCurrent(ctx, workspace.ID)
+	if err != nil {
+		return err
+	}
+	state, err := target.StateVersions.Download(ctx, stateVersion.DownloadURL)
+	if err != nil {
+		return err
+	}
+	meta, err := utils.ParseStateMeta(state)
+	if err != nil {
+		return err
+	}
+
+	if meta.Serial != expected.Serial || meta.Lineage != expected.Lineage {
+		return fmt.Errorf("migrated state mismatch: expected serial %d and lineage '%s', got serial %d and lineage '%s'",
+			expected.Serial, expected.Lineage, meta.Serial, meta.Lineage)
+	}
+	fmt.Printf("State migrated and verified: serial %d, lineage '%s'\n", meta.Serial, meta.Lineage)
+
+	return nil
+}
+
+// listWorkspaceVariables lists all the variables associated with the given workspace
+func listWorkspaceVariables(ctx context.Context, c *tfe.Client, workspaceID string) ([]*tfe.Variable, error) {
+	var variables []*tfe.Variable
+
+	listOptions := &tfe.VariableListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		variableList, err := c.Variables.List(ctx, workspaceID, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, variableList.Items...)
+
+		if variableList.Pagination == nil || variableList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = variableList.NextPage
+	}
+
+	return variables, nil
+}
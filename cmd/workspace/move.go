@@ -0,0 +1,139 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// moveOptions represents options for move command
+type moveOptions struct {
+	*workspaceOptions
+	workspaceName string
+	selector      string
+	projectName   string
+	workers       int
+	rate          float64
+	yes           bool
+}
+
+// NewWorkspaceMoveCmd returns new workspace move command
+func NewWorkspaceMoveCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &moveOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "move",
+		Aliases: []string{"mv"},
+		Short:   "move workspace(s) to another project",
+		Long:    "move a workspace or all the workspaces matching a selector to another project",
+		Example: "tfctl ws move [--workspace=...|--selector=...] [--project=...] [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return moveWorkspaces(cmd, options)
+		},
+	}
+
+	addTargetFlags(cmd, &options.workspaceName, &options.selector)
+	cmd.Flags().StringVarP(&options.projectName, "project", "p", "", "name of the project to move workspace(s) to")
+	cmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of workspaces moved concurrently")
+	cmd.Flags().Float64Var(&options.rate, "rate", 5, "Optional: Maximum number of move requests per second")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation of moving workspaces matching a selector")
+	if err := cmd.MarkFlagRequired("project"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func moveWorkspaces(cmd *cobra.Command, options *moveOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// List projects with provided name and filter project ID
+	projectList, err := c.Projects.List(ctx, options.TerraformOrganization, &tfe.ProjectListOptions{Name: options.projectName})
+	if err != nil {
+		return err
+	}
+	projectID := utils.GetProjectID(projectList, options.projectName)
+	if projectID == "" {
+		return fmt.Errorf("project '%s' not found in organization '%s'", options.projectName, options.TerraformOrganization)
+	}
+
+	workspaces, err := resolveWorkspaces(ctx, c, options.TerraformOrganization, options.workspaceName, options.selector)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	var pending []*tfe.Workspace
+	for _, workspace := range workspaces {
+		if workspace.Project != nil && workspace.Project.ID == projectID {
+			continue
+		}
+		rows = append(rows, []string{workspace.Name, workspaceProjectName(workspace), options.projectName})
+		pending = append(pending, workspace)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("%d workspace(s) already in project '%s', nothing to move\n", len(workspaces), options.projectName)
+		return nil
+	}
+
+	if options.selector != "" {
+		output.TableOutput([]string{"WORKSPACE", "FROM", "TO"}, rows)
+		fmt.Printf("\n%d of %d matching workspace(s) will be moved\n", len(pending), len(workspaces))
+		if !options.yes {
+			confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Move workspaces?")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Move canceled")
+				return nil
+			}
+		}
+	}
+
+	// Move the workspaces concurrently
+	errs := worker.Run(ctx, pending, worker.Options{Workers: options.workers, Rate: options.rate},
+		func(ctx context.Context, workspace *tfe.Workspace) error {
+			_, err := c.Workspaces.UpdateByID(ctx, workspace.ID, tfe.WorkspaceUpdateOptions{Project: &tfe.Project{ID: projectID}})
+			return err
+		})
+
+	return reportWorkspaceResults(pending, errs, "move")
+}
+
+// workspaceProjectName returns name of the workspace project or its ID if project was not included
+func workspaceProjectName(workspace *tfe.Workspace) string {
+	if workspace.Project == nil {
+		return ""
+	}
+	if workspace.Project.Name != "" {
+		return workspace.Project.Name
+	}
+
+	return workspace.Project.ID
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// outputsOptions represents options for outputs command
+type outputsOptions struct {
+	*workspaceOptions
+	workspaceName string
+	outputFormat  string
+	raw           string
+	showSensitive bool
+}
+
+// NewWorkspaceOutputsCmd returns new workspace outputs command
+func NewWorkspaceOutputsCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &outputsOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "outputs",
+		Short: "read the outputs of the current workspace state version",
+		Long: "read the outputs of the current workspace state version in script-friendly formats. " +
+			"Sensitive outputs are omitted unless --show-sensitive is set (listed on stderr for json and as comments in other formats)",
+		Example: "tfctl ws outputs [--workspace=...] [--output=json|env|dotenv|tfvars] [--raw=...] [--show-sensitive]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getWorkspaceOutputs(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name for reading outputs")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", "json", "Optional: Output format (json, env, dotenv or tfvars)")
+	cmd.Flags().StringVar(&options.raw, "raw", "", "Optional: Print only the value of the output with given name")
+	cmd.Flags().BoolVar(&options.showSensitive, "show-sensitive", false, "Optional: Print values of sensitive outputs")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func getWorkspaceOutputs(cmd *cobra.Command, options *outputsOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Check if workspace exists and got its ID
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	outputs, err := readCurrentOutputs(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	if options.raw != "" {
+		return printRawOutput(ctx, c, outputs, options)
+	}
+
+	var redacted []string
+	values := make([]output.NamedValue, 0, len(outputs))
+	for _, o := range outputs {
+		value, err := outputValue(ctx, c, o, options.showSensitive)
+		if err != nil {
+			return err
+		}
+		if o.Sensitive && !options.showSensitive {
+			redacted = append(redacted, o.Name)
+		}
+		values = append(values, output.NamedValue{Name: o.Name, Value: value, Redacted: o.Sensitive && !options.showSensitive})
+	}
+
+	res, err := output.FormatValues(values, options.outputFormat)
+	if err != nil {
+		return err
+	}
+	fmt.Print(res)
+
+	// JSON can't contain comments, so omitted outputs are listed separately
+	if options.outputFormat == "json" && len(redacted) > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Sensitive outputs omitted (use --show-sensitive to include them): %s\n", strings.Join(redacted, ", "))
+	}
+
+	return nil
+}
+
+func printRawOutput(ctx context.Context, c *tfe.Client, outputs []*tfe.StateVersionOutput, options *outputsOptions) error {
+	for _, o := range outputs {
+		if o.Name != options.raw {
+			continue
+		}
+		if o.Sensitive && !options.showSensitive {
+			return fmt.Errorf("output '%s' is sensitive, use --show-sensitive to print its value", o.Name)
+		}
+
+		value, err := outputValue(ctx, c, o, true)
+		if err != nil {
+			return err
+		}
+		res, err := output.FormatRaw(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(res)
+
+		return nil
+	}
+
+	return fmt.Errorf("output '%s' not found in the current state of workspace '%s'", options.raw, options.workspaceName)
+}
+
+// outputValue returns value of the output, sensitive values are read separately as they are omitted in the list
+func outputValue(ctx context.Context, c *tfe.Client, o *tfe.StateVersionOutput, showSensitive bool) (interface{}, error) {
+	if !o.Sensitive {
+		return o.Value, nil
+	}
+	if !showSensitive {
+		return output.RedactedValue, nil
+	}
+
+	sensitiveOutput, err := c.StateVersionOutputs.Read(ctx, o.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sensitiveOutput.Value, nil
+}
+
+// readCurrentOutputs reads the outputs of the current state version, empty if workspace has no state yet
+func readCurrentOutputs(ctx context.Context, c *tfe.Client, workspaceID string) ([]*tfe.StateVersionOutput, error) {
+	outputList, err := c.StateVersionOutputs.ReadCurrent(ctx, workspaceID)
+	if errors.Is(err, tfe.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return outputList.Items, nil
+}
@@ -0,0 +1,323 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/selector"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// remoteStateOptions represents options for remote state sharing commands
+type remoteStateOptions struct {
+	*workspaceOptions
+	workspaceName string
+	consumers     []string
+	enabled       bool
+	selector      string
+}
+
+// remoteStateSharing represents remote state sharing settings of a workspace
+type remoteStateSharing struct {
+	Workspace         string   `json:"workspace"`
+	GlobalRemoteState bool     `json:"global-remote-state"`
+	Consumers         []string `json:"consumers"`
+}
+
+// NewWorkspaceRemoteStateCmd returns new workspace remote state sharing command
+func NewWorkspaceRemoteStateCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "remote-state",
+		Aliases: []string{"rs"},
+		Short:   "manage which workspaces may read the state of a workspace",
+		Long: "manage remote state sharing of a workspace: share the state with all the workspaces within " +
+			"an organization (global remote state) or with explicit list of consumer workspaces",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateShowCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateAllowCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateDenyCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateSetGlobalCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateReportCmd(workspaceOptions))
+
+	return cobraCmd
+}
+
+// NewWorkspaceRemoteStateShowCmd returns new workspace remote state show command
+func NewWorkspaceRemoteStateShowCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &remoteStateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show",
+		Aliases: []string{"get"},
+		Short:   "show remote state sharing settings of a workspace",
+		Long:    "show whether the state of a workspace is shared globally and list of workspaces allowed to read it",
+		Example: "tfctl ws remote-state show [--workspace=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showRemoteState(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceRemoteStateAllowCmd returns new workspace remote state allow command
+func NewWorkspaceRemoteStateAllowCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceRemoteStateConsumersCmd(workspaceOptions, "allow", "allow workspaces to read the state of a workspace",
+		func(ctx context.Context, c *tfe.Client, workspaceID string, consumers []*tfe.Workspace) error {
+			return c.Workspaces.AddRemoteStateConsumers(ctx, workspaceID, tfe.WorkspaceAddRemoteStateConsumersOptions{Workspaces: consumers})
+		})
+}
+
+// NewWorkspaceRemoteStateDenyCmd returns new workspace remote state deny command
+func NewWorkspaceRemoteStateDenyCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceRemoteStateConsumersCmd(workspaceOptions, "deny", "deny workspaces to read the state of a workspace",
+		func(ctx context.Context, c *tfe.Client, workspaceID string, consumers []*tfe.Workspace) error {
+			return c.Workspaces.RemoveRemoteStateConsumers(ctx, workspaceID, tfe.WorkspaceRemoveRemoteStateConsumersOptions{Workspaces: consumers})
+		})
+}
+
+func newWorkspaceRemoteStateConsumersCmd(workspaceOptions *workspaceOptions, use, description string,
+	change func(context.Context, *tfe.Client, string, []*tfe.Workspace) error) *cobra.Command {
+	options := &remoteStateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   description,
+		Long:    description + ". Consumers are ignored while the state is shared globally",
+		Example: "tfctl ws remote-state " + use + " [--workspace=...] [--consumer=...] [--consumer=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeRemoteStateConsumers(cmd, options, use, change)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name sharing its state")
+	cmd.Flags().StringArrayVarP(&options.consumers, "consumer", "c", nil, "name of consumer workspace, can be repeated")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("consumer"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceRemoteStateSetGlobalCmd returns new workspace remote state set-global command
+func NewWorkspaceRemoteStateSetGlobalCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &remoteStateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "set-global",
+		Short:   "share the state of a workspace with all the workspaces within an organization",
+		Long:    "enable (or disable with --enabled=false) sharing the state of a workspace with all the workspaces within an organization",
+		Example: "tfctl ws remote-state set-global [--workspace=...] [--enabled=false]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setGlobalRemoteState(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().BoolVar(&options.enabled, "enabled", true, "Optional: Share state globally (true) or only with explicit consumers (false)")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceRemoteStateReportCmd returns new workspace remote state report command
+func NewWorkspaceRemoteStateReportCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &remoteStateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "list all the workspaces sharing their state with the whole organization",
+		Long:    "list all (or selected) workspaces with global remote state sharing enabled",
+		Example: "tfctl ws remote-state report [--selector=tag=prod]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return reportGlobalRemoteState(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"Optional: workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+
+	return cmd
+}
+
+func showRemoteState(_ *cobra.Command, options *remoteStateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	consumers, err := listRemoteStateConsumers(ctx, c, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	sharing := &remoteStateSharing{Workspace: workspace.Name, GlobalRemoteState: workspace.GlobalRemoteState, Consumers: []string{}}
+	for _, consumer := range consumers {
+		sharing.Consumers = append(sharing.Consumers, consumer.Name)
+	}
+	sort.Strings(sharing.Consumers)
+
+	if options.Expand {
+		output.JsonOutput(sharing)
+		return nil
+	}
+
+	fmt.Printf("Global remote state: %t\n", sharing.GlobalRemoteState)
+	if sharing.GlobalRemoteState {
+		fmt.Println("State is shared with all the workspaces within organization '" + options.TerraformOrganization + "'")
+	}
+	printDescriptionList("Remote state consumers", sharing.Consumers)
+
+	return nil
+}
+
+func changeRemoteStateConsumers(_ *cobra.Command, options *remoteStateOptions, operation string,
+	change func(context.Context, *tfe.Client, string, []*tfe.Workspace) error) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// Read consumer workspaces to get their IDs
+	var consumers []*tfe.Workspace
+	for _, name := range options.consumers {
+		consumer, err := c.Workspaces.Read(ctx, options.TerraformOrganization, name)
+		if err != nil {
+			return fmt.Errorf("consumer workspace '%s': %w", name, err)
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	if err := change(ctx, c, workspace.ID, consumers); err != nil {
+		return err
+	}
+	fmt.Printf("Remote state consumers of workspace '%s' updated successfully (%s %d workspace(s))!\n",
+		workspace.Name, operation, len(consumers))
+	if workspace.GlobalRemoteState {
+		fmt.Println("WARNING: state of the workspace is shared globally, " +
+			"consumers list takes effect only after 'tfctl ws remote-state set-global --enabled=false'")
+	}
+
+	return nil
+}
+
+func setGlobalRemoteState(_ *cobra.Command, options *remoteStateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Update global remote state setting of a workspace
+	workspace, err := c.Workspaces.Update(ctx, options.TerraformOrganization, options.workspaceName, tfe.WorkspaceUpdateOptions{
+		GlobalRemoteState: tfe.Bool(options.enabled),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println("Global remote state of workspace '" + workspace.Name + "' set to " + strconv.FormatBool(workspace.GlobalRemoteState))
+
+	return nil
+}
+
+func reportGlobalRemoteState(_ *cobra.Command, options *remoteStateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// All the workspaces of the organization are used if selector is not set
+	sel, err := selector.ParseOptional(options.selector)
+	if err != nil {
+		return err
+	}
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+
+	var exposed []*tfe.Workspace
+	for _, workspace := range workspaces {
+		if workspace.GlobalRemoteState {
+			exposed = append(exposed, workspace)
+		}
+	}
+	sort.Slice(exposed, func(i, j int) bool { return exposed[i].Name < exposed[j].Name })
+
+	if options.Expand {
+		output.JsonOutput(exposed)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(exposed))
+	for _, workspace := range exposed {
+		rows = append(rows, []string{workspace.Name, workspaceProjectName(workspace), strconv.Itoa(workspace.ResourceCount)})
+	}
+	output.TableOutput([]string{"WORKSPACE", "PROJECT", "RESOURCES"}, rows)
+	fmt.Printf("\n%d of %d workspace(s) share their state with the whole organization\n", len(exposed), len(workspaces))
+
+	return nil
+}
+
+// listRemoteStateConsumers returns all the workspaces allowed to read the state of a workspace
+func listRemoteStateConsumers(ctx context.Context, c *tfe.Client, workspaceID string) ([]*tfe.Workspace, error) {
+	var consumers []*tfe.Workspace
+	listOptions := &tfe.RemoteStateConsumersListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		consumerList, err := c.Workspaces.ListRemoteStateConsumers(ctx, workspaceID, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumerList.Items...)
+		if consumerList.Pagination == nil || consumerList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = consumerList.NextPage
+	}
+
+	return consumers, nil
+}
@@ -0,0 +1,307 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// tagsOptions represents options shared by workspace tags commands
+type tagsOptions struct {
+	*workspaceOptions
+	workspaceName string
+	selector      string
+	tags          []string
+	clear         bool
+	yes           bool
+}
+
+// NewWorkspaceTagsCmd returns new workspace tags command
+func NewWorkspaceTagsCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "tags",
+		Aliases: []string{"tag"},
+		Short:   "manage workspace tags and key-value tag bindings",
+		Long:    "manage workspace tags and key-value tag bindings. Tags in 'key=value' format are treated as tag bindings",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewWorkspaceTagsListCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceTagsAddCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceTagsRemoveCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceTagsSetCmd(workspaceOptions))
+
+	return cobraCmd
+}
+
+// NewWorkspaceTagsListCmd returns new workspace tags list command
+func NewWorkspaceTagsListCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &tagsOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list tags and tag bindings of a workspace",
+		Long:    "list tags and tag bindings of a workspace",
+		Example: "tfctl ws tags list [--workspace=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listWorkspaceTags(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name for listing tags")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceTagsAddCmd returns new workspace tags add command
+func NewWorkspaceTagsAddCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceTagsChangeCmd(workspaceOptions, "add",
+		"add tags to a workspace or all the workspaces matching a selector, existing tag binding values are replaced",
+		addWorkspaceTags)
+}
+
+// NewWorkspaceTagsRemoveCmd returns new workspace tags remove command
+func NewWorkspaceTagsRemoveCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceTagsChangeCmd(workspaceOptions, "remove",
+		"remove tags from a workspace or all the workspaces matching a selector, 'key=' removes a tag binding with any value",
+		removeWorkspaceTags)
+}
+
+// NewWorkspaceTagsSetCmd returns new workspace tags set command
+func NewWorkspaceTagsSetCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceTagsChangeCmd(workspaceOptions, "set",
+		"replace all tags and tag bindings of a workspace or all the workspaces matching a selector",
+		setWorkspaceTags)
+}
+
+func newWorkspaceTagsChangeCmd(workspaceOptions *workspaceOptions, use, description string,
+	change func(context.Context, *tfe.Client, *tfe.Workspace, []string, []*tfe.TagBinding) error) *cobra.Command {
+	options := &tagsOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   description,
+		Long:    description,
+		Example: "tfctl ws tags " + use + " [--workspace=...|--selector=...] [--tag=prod] [--tag=env=production] [--yes]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeWorkspaceTags(cmd, options, use, change)
+		},
+	}
+
+	addTargetFlags(cmd, &options.workspaceName, &options.selector)
+	cmd.Flags().StringArrayVarP(&options.tags, "tag", "t", nil, "tag name or 'key=value' tag binding, can be repeated")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation of changes in workspaces matching a selector")
+	if use != "set" {
+		if err := cmd.MarkFlagRequired("tag"); err != nil {
+			return nil
+		}
+		return cmd
+	}
+
+	// Removing all the tags must be requested explicitly
+	cmd.Flags().BoolVar(&options.clear, "clear", false, "Optional: Remove all tags and tag bindings")
+	cmd.MarkFlagsOneRequired("tag", "clear")
+	cmd.MarkFlagsMutuallyExclusive("tag", "clear")
+
+	return cmd
+}
+
+func listWorkspaceTags(_ *cobra.Command, options *tagsOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Check if workspace exists and got its ID
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// List all tag bindings associated with the workspace
+	bindings, err := c.Workspaces.ListTagBindings(ctx, workspace.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range workspace.TagNames {
+		fmt.Println(tag)
+	}
+	for _, binding := range bindings {
+		fmt.Println(binding.Key + "=" + binding.Value)
+	}
+
+	return nil
+}
+
+func changeWorkspaceTags(cmd *cobra.Command, options *tagsOptions, use string,
+	change func(context.Context, *tfe.Client, *tfe.Workspace, []string, []*tfe.TagBinding) error) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	names, bindings, err := utils.ParseTags(options.tags)
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := resolveWorkspaces(ctx, c, options.TerraformOrganization, options.workspaceName, options.selector)
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		fmt.Println("No workspaces match the selector")
+		return nil
+	}
+
+	// Preview changes of the workspaces matching the selector before applying them
+	if options.selector != "" && !options.yes {
+		newTags := strings.Join(options.tags, ", ")
+		if newTags == "" {
+			newTags = "(none)"
+		}
+		rows := make([][]string, 0, len(workspaces))
+		for _, workspace := range workspaces {
+			rows = append(rows, []string{workspace.Name, strings.Join(workspace.TagNames, ", "), use, newTags})
+		}
+		output.TableOutput([]string{"WORKSPACE", "CURRENT TAGS", "ACTION", "TAGS"}, rows)
+		fmt.Printf("\n%d workspace(s) will be changed\n", len(workspaces))
+
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Apply changes?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Tags change canceled")
+			return nil
+		}
+	}
+
+	errs := worker.Run(ctx, workspaces, worker.Options{}, func(ctx context.Context, workspace *tfe.Workspace) error {
+		return change(ctx, c, workspace, names, bindings)
+	})
+
+	return reportWorkspaceResults(workspaces, errs, "tag")
+}
+
+func addWorkspaceTags(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, names []string, bindings []*tfe.TagBinding) error {
+	if len(names) > 0 {
+		if err := c.Workspaces.AddTags(ctx, workspace.ID, tfe.WorkspaceAddTagsOptions{Tags: tagsFromNames(names)}); err != nil {
+			return err
+		}
+	}
+	if len(bindings) > 0 {
+		if _, err := c.Workspaces.AddTagBindings(ctx, workspace.ID, tfe.WorkspaceAddTagBindingsOptions{TagBindings: bindings}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removeWorkspaceTags(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, names []string, bindings []*tfe.TagBinding) error {
+	if len(names) > 0 {
+		if err := c.Workspaces.RemoveTags(ctx, workspace.ID, tfe.WorkspaceRemoveTagsOptions{Tags: tagsFromNames(names)}); err != nil {
+			return err
+		}
+	}
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	current, err := c.Workspaces.ListTagBindings(ctx, workspace.ID)
+	if err != nil {
+		return err
+	}
+	remaining := utils.RemoveTagBindings(current, bindings)
+	if len(remaining) == len(current) {
+		return nil
+	}
+
+	return replaceTagBindings(ctx, c, workspace, remaining)
+}
+
+func setWorkspaceTags(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, names []string, bindings []*tfe.TagBinding) error {
+	var added, removed []string
+	for _, name := range names {
+		if !containsString(workspace.TagNames, name) {
+			added = append(added, name)
+		}
+	}
+	for _, name := range workspace.TagNames {
+		if !containsString(names, name) {
+			removed = append(removed, name)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := c.Workspaces.RemoveTags(ctx, workspace.ID, tfe.WorkspaceRemoveTagsOptions{Tags: tagsFromNames(removed)}); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		if err := c.Workspaces.AddTags(ctx, workspace.ID, tfe.WorkspaceAddTagsOptions{Tags: tagsFromNames(added)}); err != nil {
+			return err
+		}
+	}
+
+	return replaceTagBindings(ctx, c, workspace, bindings)
+}
+
+// replaceTagBindings replaces all tag bindings of the workspace with given ones
+func replaceTagBindings(ctx context.Context, c *tfe.Client, workspace *tfe.Workspace, bindings []*tfe.TagBinding) error {
+	if len(bindings) == 0 {
+		return c.Workspaces.DeleteAllTagBindings(ctx, workspace.ID)
+	}
+
+	_, err := c.Workspaces.UpdateByID(ctx, workspace.ID, tfe.WorkspaceUpdateOptions{TagBindings: bindings})
+
+	return err
+}
+
+func tagsFromNames(names []string) []*tfe.Tag {
+	tags := make([]*tfe.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &tfe.Tag{Name: name})
+	}
+
+	return tags
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+
+	"github.com/ealebed/tfctl/pkg/selector"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// addTargetFlags adds mutually exclusive '--workspace' and '--selector' flags for commands working with one or many workspaces
+func addTargetFlags(cmd *cobra.Command, workspaceName, selectorString *string) {
+	cmd.Flags().StringVarP(workspaceName, "workspace", "w", "", "terraform workspace name")
+	cmd.Flags().StringVarP(selectorString, "selector", "s", "",
+		"workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cmd.MarkFlagsOneRequired("workspace", "selector")
+	cmd.MarkFlagsMutuallyExclusive("workspace", "selector")
+}
+
+// resolveWorkspaces returns the workspace with given name or all the workspaces matching the selector
+func resolveWorkspaces(ctx context.Context, c *tfe.Client, organization, workspaceName, selectorString string) ([]*tfe.Workspace, error) {
+	if workspaceName != "" {
+		// Read a workspace by its name and organization name including its project
+		workspace, err := c.Workspaces.ReadWithOptions(ctx, organization, workspaceName, &tfe.WorkspaceReadOptions{
+			Include: []tfe.WSIncludeOpt{tfe.WSProject},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []*tfe.Workspace{workspace}, nil
+	}
+
+	sel, err := selector.Parse(selectorString)
+	if err != nil {
+		return nil, err
+	}
+
+	return selector.Resolve(ctx, c, organization, sel)
+}
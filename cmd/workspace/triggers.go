@@ -0,0 +1,203 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ealebed/tfctl/pkg/graph"
+	"github.com/ealebed/tfctl/pkg/output"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// triggersOptions represents options for run triggers commands
+type triggersOptions struct {
+	*workspaceOptions
+	workspaceName string
+	sources       []string
+}
+
+// NewWorkspaceTriggersCmd returns new workspace run triggers command
+func NewWorkspaceTriggersCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	cobraCmd := &cobra.Command{
+		Use:     "triggers",
+		Aliases: []string{"trigger"},
+		Short:   "manage run triggers between workspaces",
+		Long:    "manage run triggers of a workspace: a successful apply in a source workspace queues a run in the workspace",
+		Example: "",
+	}
+
+	// create subcommands
+	cobraCmd.AddCommand(NewWorkspaceTriggersListCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceTriggersAddCmd(workspaceOptions))
+	cobraCmd.AddCommand(NewWorkspaceTriggersRemoveCmd(workspaceOptions))
+
+	return cobraCmd
+}
+
+// NewWorkspaceTriggersListCmd returns new workspace run triggers list command
+func NewWorkspaceTriggersListCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &triggersOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list run triggers of a workspace",
+		Long:    "list inbound (queueing runs in the workspace) and outbound (queueing runs in other workspaces) run triggers of a workspace",
+		Example: "tfctl ws triggers list [--workspace=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listRunTriggers(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+// NewWorkspaceTriggersAddCmd returns new workspace run triggers add command
+func NewWorkspaceTriggersAddCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceTriggersChangeCmd(workspaceOptions, "add", "add run triggers queueing runs in a workspace after applies in source workspaces")
+}
+
+// NewWorkspaceTriggersRemoveCmd returns new workspace run triggers remove command
+func NewWorkspaceTriggersRemoveCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	return newWorkspaceTriggersChangeCmd(workspaceOptions, "remove", "remove run triggers of a workspace from source workspaces")
+}
+
+func newWorkspaceTriggersChangeCmd(workspaceOptions *workspaceOptions, use, description string) *cobra.Command {
+	options := &triggersOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   description,
+		Long:    description,
+		Example: "tfctl ws triggers " + use + " [--workspace=...] [--source=...] [--source=...]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeRunTriggers(cmd, options, use)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.workspaceName, "workspace", "w", "", "terraform workspace name where runs are queued")
+	cmd.Flags().StringArrayVar(&options.sources, "source", nil, "name of source workspace, can be repeated")
+	if err := cmd.MarkFlagRequired("workspace"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("source"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func listRunTriggers(_ *cobra.Command, options *triggersOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	inbound, err := graph.ListRunTriggers(ctx, c, workspace.ID, tfe.RunTriggerInbound)
+	if err != nil {
+		return err
+	}
+	outbound, err := graph.ListRunTriggers(ctx, c, workspace.ID, tfe.RunTriggerOutbound)
+	if err != nil {
+		return err
+	}
+	runTriggers := append(inbound, outbound...)
+
+	if options.Expand {
+		output.JsonOutput(runTriggers)
+		return nil
+	}
+
+	rows := make([][]string, 0, len(runTriggers))
+	for i, runTrigger := range runTriggers {
+		direction := string(tfe.RunTriggerInbound)
+		if i >= len(inbound) {
+			direction = string(tfe.RunTriggerOutbound)
+		}
+		rows = append(rows, []string{
+			direction,
+			runTrigger.SourceableName,
+			runTrigger.WorkspaceName,
+			runTrigger.ID,
+			runTrigger.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	output.TableOutput([]string{"DIRECTION", "SOURCE", "WORKSPACE", "ID", "CREATED"}, rows)
+
+	return nil
+}
+
+func changeRunTriggers(_ *cobra.Command, options *triggersOptions, operation string) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	// Read a workspace by its name and organization name
+	workspace, err := c.Workspaces.Read(ctx, options.TerraformOrganization, options.workspaceName)
+	if err != nil {
+		return err
+	}
+
+	inbound, err := graph.ListRunTriggers(ctx, c, workspace.ID, tfe.RunTriggerInbound)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]*tfe.RunTrigger, len(inbound))
+	for _, runTrigger := range inbound {
+		existing[runTrigger.SourceableName] = runTrigger
+	}
+
+	changed := 0
+	for _, name := range options.sources {
+		runTrigger, exists := existing[name]
+		switch {
+		case operation == "add" && exists, operation == "remove" && !exists:
+			continue
+		case operation == "remove":
+			err = c.RunTriggers.Delete(ctx, runTrigger.ID)
+		default:
+			source, readErr := c.Workspaces.Read(ctx, options.TerraformOrganization, name)
+			if readErr != nil {
+				return fmt.Errorf("source workspace '%s': %w", name, readErr)
+			}
+			_, err = c.RunTriggers.Create(ctx, workspace.ID, tfe.RunTriggerCreateOptions{Sourceable: source})
+		}
+		if err != nil {
+			return fmt.Errorf("source workspace '%s': %w", name, err)
+		}
+		changed++
+	}
+	fmt.Printf("Run triggers of workspace '%s' updated successfully (%s %d of %d source workspace(s))!\n",
+		workspace.Name, operation, changed, len(options.sources))
+
+	return nil
+}
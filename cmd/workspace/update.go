@@ -0,0 +1,272 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ealebed/tfctl/pkg/output"
+	"github.com/ealebed/tfctl/pkg/selector"
+	"github.com/ealebed/tfctl/pkg/worker"
+	"github.com/ealebed/tfctl/utils"
+
+	"github.com/hashicorp/go-tfe"
+	"github.com/spf13/cobra"
+)
+
+// updateOptions represents options for update command
+type updateOptions struct {
+	*workspaceOptions
+	selector string
+	settings []string
+	workers  int
+	rate     float64
+	yes      bool
+}
+
+// workspaceSetting describes a workspace attribute which can be changed with '--set key=value'
+type workspaceSetting struct {
+	current func(*tfe.Workspace) string
+	// apply sets the value to update options and returns it normalized for comparison with current one
+	apply func(*tfe.WorkspaceUpdateOptions, string) (string, error)
+}
+
+// settingChange represents a single requested '--set key=value' change
+type settingChange struct {
+	key   string
+	value string
+}
+
+var workspaceSettings = map[string]workspaceSetting{
+	"terraform-version": stringSetting(
+		func(w *tfe.Workspace) string { return w.TerraformVersion },
+		func(o *tfe.WorkspaceUpdateOptions, v *string) { o.TerraformVersion = v }),
+	"description": stringSetting(
+		func(w *tfe.Workspace) string { return w.Description },
+		func(o *tfe.WorkspaceUpdateOptions, v *string) { o.Description = v }),
+	"execution-mode": stringSetting(
+		func(w *tfe.Workspace) string { return w.ExecutionMode },
+		func(o *tfe.WorkspaceUpdateOptions, v *string) { o.ExecutionMode = v }),
+	"working-directory": stringSetting(
+		func(w *tfe.Workspace) string { return w.WorkingDirectory },
+		func(o *tfe.WorkspaceUpdateOptions, v *string) { o.WorkingDirectory = v }),
+	"auto-apply": boolSetting(
+		func(w *tfe.Workspace) bool { return w.AutoApply },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.AutoApply = v }),
+	"auto-apply-run-trigger": boolSetting(
+		func(w *tfe.Workspace) bool { return w.AutoApplyRunTrigger },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.AutoApplyRunTrigger = v }),
+	"allow-destroy-plan": boolSetting(
+		func(w *tfe.Workspace) bool { return w.AllowDestroyPlan },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.AllowDestroyPlan = v }),
+	"assessments-enabled": boolSetting(
+		func(w *tfe.Workspace) bool { return w.AssessmentsEnabled },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.AssessmentsEnabled = v }),
+	"file-triggers-enabled": boolSetting(
+		func(w *tfe.Workspace) bool { return w.FileTriggersEnabled },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.FileTriggersEnabled = v }),
+	"queue-all-runs": boolSetting(
+		func(w *tfe.Workspace) bool { return w.QueueAllRuns },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.QueueAllRuns = v }),
+	"speculative-enabled": boolSetting(
+		func(w *tfe.Workspace) bool { return w.SpeculativeEnabled },
+		func(o *tfe.WorkspaceUpdateOptions, v *bool) { o.SpeculativeEnabled = v }),
+}
+
+func stringSetting(current func(*tfe.Workspace) string, set func(*tfe.WorkspaceUpdateOptions, *string)) workspaceSetting {
+	return workspaceSetting{
+		current: current,
+		apply: func(o *tfe.WorkspaceUpdateOptions, value string) (string, error) {
+			set(o, tfe.String(value))
+			return value, nil
+		},
+	}
+}
+
+func boolSetting(current func(*tfe.Workspace) bool, set func(*tfe.WorkspaceUpdateOptions, *bool)) workspaceSetting {
+	return workspaceSetting{
+		current: func(w *tfe.Workspace) string { return strconv.FormatBool(current(w)) },
+		apply: func(o *tfe.WorkspaceUpdateOptions, value string) (string, error) {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid boolean value '%s'", value)
+			}
+			set(o, tfe.Bool(b))
+			return strconv.FormatBool(b), nil
+		},
+	}
+}
+
+// NewWorkspaceUpdateCmd returns new workspace update command
+func NewWorkspaceUpdateCmd(workspaceOptions *workspaceOptions) *cobra.Command {
+	options := &updateOptions{
+		workspaceOptions: workspaceOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update settings of all the workspaces matching a selector",
+		Long: "update settings of all the workspaces matching a selector. Supported settings: " +
+			strings.Join(supportedSettings(), ", "),
+		Example: "tfctl ws update --selector 'tag=prod,project=payments' --set terraform-version=1.9.5 [--set auto-apply=true]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateWorkspaces(cmd, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.selector, "selector", "s", "",
+		"workspace selector, comma separated 'name=<glob>', 'tag=<tag>', '!tag=<tag>' and 'project=<name>' conditions")
+	cmd.Flags().StringArrayVar(&options.settings, "set", nil, "workspace setting to change in 'key=value' format, can be repeated")
+	cmd.Flags().IntVar(&options.workers, "workers", worker.DefaultWorkers, "Optional: Number of workspaces updated concurrently")
+	cmd.Flags().Float64Var(&options.rate, "rate", 5, "Optional: Maximum number of update requests per second")
+	cmd.Flags().BoolVarP(&options.yes, "yes", "y", false, "Optional: Skip interactive confirmation")
+	if err := cmd.MarkFlagRequired("selector"); err != nil {
+		return nil
+	}
+	if err := cmd.MarkFlagRequired("set"); err != nil {
+		return nil
+	}
+
+	return cmd
+}
+
+func updateWorkspaces(cmd *cobra.Command, options *updateOptions) error {
+	c := options.TClient
+	ctx := context.Background()
+
+	sel, err := selector.Parse(options.selector)
+	if err != nil {
+		return err
+	}
+
+	changes, updateOptions, err := parseSettingChanges(options.settings)
+	if err != nil {
+		return err
+	}
+
+	// List all the workspaces within an organization matching the selector
+	workspaces, err := selector.Resolve(ctx, c, options.TerraformOrganization, sel)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	var pending []*tfe.Workspace
+	for _, workspace := range workspaces {
+		changed := false
+		for _, change := range changes {
+			current := workspaceSettings[change.key].current(workspace)
+			if current != change.value {
+				rows = append(rows, []string{workspace.Name, change.key, current, change.value})
+				changed = true
+			}
+		}
+		if changed {
+			pending = append(pending, workspace)
+		}
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("%d workspace(s) match the selector, nothing to update\n", len(workspaces))
+		return nil
+	}
+
+	output.TableOutput([]string{"WORKSPACE", "SETTING", "CURRENT", "NEW"}, rows)
+	fmt.Printf("\n%d of %d matching workspace(s) will be updated\n", len(pending), len(workspaces))
+
+	if !options.yes {
+		confirmed, err := utils.Confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "Apply changes?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Update canceled")
+			return nil
+		}
+	}
+
+	// Update settings of the workspaces concurrently
+	errs := worker.Run(ctx, pending, worker.Options{Workers: options.workers, Rate: options.rate},
+		func(ctx context.Context, workspace *tfe.Workspace) error {
+			_, err := c.Workspaces.UpdateByID(ctx, workspace.ID, updateOptions)
+			return err
+		})
+
+	return reportWorkspaceResults(pending, errs, "update")
+}
+
+// parseSettingChanges validates '--set key=value' flags and fills workspace update options from them
+func parseSettingChanges(settings []string) ([]settingChange, tfe.WorkspaceUpdateOptions, error) {
+	var changes []settingChange
+	updateOptions := tfe.WorkspaceUpdateOptions{}
+
+	for _, s := range settings {
+		key, value, found := strings.Cut(s, "=")
+		if !found {
+			return nil, updateOptions, fmt.Errorf("invalid setting '%s', expected 'key=value'", s)
+		}
+
+		setting, ok := workspaceSettings[key]
+		if !ok {
+			return nil, updateOptions, fmt.Errorf("unknown setting '%s', supported settings: %s", key, strings.Join(supportedSettings(), ", "))
+		}
+		value, err := setting.apply(&updateOptions, value)
+		if err != nil {
+			return nil, updateOptions, fmt.Errorf("setting '%s': %v", key, err)
+		}
+		changes = append(changes, settingChange{key: key, value: value})
+	}
+
+	return changes, updateOptions, nil
+}
+
+// reportWorkspaceResults prints per-workspace results of a bulk operation and returns error if any of them failed
+func reportWorkspaceResults(workspaces []*tfe.Workspace, errs []error, operation string) error {
+	var rows [][]string
+	failed := 0
+	for i, workspace := range workspaces {
+		if errs[i] != nil {
+			failed++
+			rows = append(rows, []string{workspace.Name, "FAILED", errs[i].Error()})
+		} else {
+			rows = append(rows, []string{workspace.Name, "OK", ""})
+		}
+	}
+
+	fmt.Println()
+	output.TableOutput([]string{"WORKSPACE", "RESULT", "ERROR"}, rows)
+
+	if failed > 0 {
+		return fmt.Errorf("failed to %s %d of %d workspace(s)", operation, failed, len(workspaces))
+	}
+	fmt.Printf("\n%d workspace(s) processed successfully!\n", len(workspaces))
+
+	return nil
+}
+
+func supportedSettings() []string {
+	keys := make([]string, 0, len(workspaceSettings))
+	for key := range workspaceSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -46,9 +46,18 @@ func NewWorkspaceCmd(rootOptions *cmd.RootOptions) *cobra.Command {
 
 	// create subcommands
 	cobraCmd.AddCommand(NewWorkspaceGetCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceDescribeCmd(options))
 	cobraCmd.AddCommand(NewWorkspaceListCmd(options))
 	cobraCmd.AddCommand(NewWorkspaceSaveCmd(options))
 	cobraCmd.AddCommand(NewWorkspaceDeleteCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceUpdateCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceMoveCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceMigrateCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceOutputsCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceTagsCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceAccessCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceRemoteStateCmd(options))
+	cobraCmd.AddCommand(NewWorkspaceTriggersCmd(options))
 
 	return cobraCmd
 }
@@ -0,0 +1,83 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost contains helpers for reading cost estimation results of terraform runs.
+package cost
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ResourceCost represents estimated monthly cost of a single resource
+type ResourceCost struct {
+	Address             string `json:"address"`
+	Type                string `json:"type"`
+	PriorMonthlyCost    string `json:"prior-monthly-cost"`
+	ProposedMonthlyCost string `json:"proposed-monthly-cost"`
+	DeltaMonthlyCost    string `json:"delta-monthly-cost"`
+}
+
+// Breakdown represents per-resource details of a cost estimate (cost estimate log)
+type Breakdown struct {
+	Resources struct {
+		// Matched are resources with known prices
+		Matched []ResourceCost `json:"matched"`
+		// Unmatched are resources which cost can't be estimated
+		Unmatched []ResourceCost `json:"unmatched"`
+	} `json:"resources"`
+}
+
+// ParseBreakdown parses cost estimate log and sorts resources by address
+func ParseBreakdown(data []byte) (*Breakdown, error) {
+	var breakdown Breakdown
+	if err := json.Unmarshal(data, &breakdown); err != nil {
+		return nil, fmt.Errorf("failed to parse cost estimate: %v", err)
+	}
+
+	for _, resources := range [][]ResourceCost{breakdown.Resources.Matched, breakdown.Resources.Unmatched} {
+		sort.Slice(resources, func(i, j int) bool { return resources[i].Address < resources[j].Address })
+	}
+
+	return &breakdown, nil
+}
+
+// ParseAmount parses monthly cost amount, empty amount is zero
+func ParseAmount(amount string) (float64, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cost amount '%s'", amount)
+	}
+
+	return value, nil
+}
+
+// FormatAmount formats monthly cost amount with cents
+func FormatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+// FormatDelta formats monthly cost change with explicit sign
+func FormatDelta(delta float64) string {
+	return fmt.Sprintf("%+.2f", delta)
+}
@@ -0,0 +1,77 @@
+package cost
+
+import (
+	"testing"
+)
+
+func TestParseBreakdown(t *testing.T) {
+	data := `{
+  "delta-monthly-cost": "25.5",
+  "resources": {
+    "matched": [
+      {"address": "aws_instance.web", "type": "aws_instance", "prior-monthly-cost": "0.0", "proposed-monthly-cost": "8.5", "delta-monthly-cost": "8.5"},
+      {"address": "aws_db_instance.main", "type": "aws_db_instance", "prior-monthly-cost": "10.0", "proposed-monthly-cost": "27.0", "delta-monthly-cost": "17.0"}
+    ],
+    "unmatched": [
+      {"address": "aws_iam_role.app", "type": "aws_iam_role"}
+    ]
+  }
+}`
+
+	breakdown, err := ParseBreakdown([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseBreakdown() unexpected error: %v", err)
+	}
+
+	matched := breakdown.Resources.Matched
+	if len(matched) != 2 {
+		t.Fatalf("ParseBreakdown() matched = %d, want 2", len(matched))
+	}
+	if matched[0].Address != "aws_db_instance.main" || matched[0].DeltaMonthlyCost != "17.0" {
+		t.Errorf("ParseBreakdown() first matched = %+v, want sorted by address", matched[0])
+	}
+	if len(breakdown.Resources.Unmatched) != 1 || breakdown.Resources.Unmatched[0].Type != "aws_iam_role" {
+		t.Errorf("ParseBreakdown() unmatched = %+v", breakdown.Resources.Unmatched)
+	}
+
+	if _, err := ParseBreakdown([]byte("not json")); err == nil {
+		t.Error("ParseBreakdown() should return error for invalid JSON")
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    float64
+		wantErr bool
+	}{
+		{amount: "12.5", want: 12.5},
+		{amount: "-3.25", want: -3.25},
+		{amount: "", want: 0},
+		{amount: " 1 ", want: 1},
+		{amount: "n/a", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.amount)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmount(%q) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %v, want %v", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if got := FormatAmount(12.5); got != "12.50" {
+		t.Errorf("FormatAmount() = %q, want %q", got, "12.50")
+	}
+	if got := FormatDelta(3); got != "+3.00" {
+		t.Errorf("FormatDelta() = %q, want %q", got, "+3.00")
+	}
+	if got := FormatDelta(-0.5); got != "-0.50" {
+		t.Errorf("FormatDelta() = %q, want %q", got, "-0.50")
+	}
+}
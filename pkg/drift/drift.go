@@ -0,0 +1,228 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift contains the report of drift detection across workspaces.
+package drift
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of drift detection in a workspace
+type Status string
+
+// Drift detection outcomes
+const (
+	Drifted Status = "drifted"
+	NoDrift Status = "no-drift"
+	Errored Status = "errored"
+	Skipped Status = "skipped"
+)
+
+// Source tells how drift of a workspace was detected
+type Source string
+
+// Drift detection sources
+const (
+	// Assessment is the latest health assessment result of a workspace
+	Assessment Source = "assessment"
+	// RefreshOnly is a refresh-only plan queued by the scan
+	RefreshOnly Source = "refresh-only"
+)
+
+// Result is the outcome of drift detection in a single workspace
+type Result struct {
+	Workspace string   `json:"workspace"`
+	Status    Status   `json:"status"`
+	Source    Source   `json:"source,omitempty"`
+	RunID     string   `json:"run-id,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	Resources []string `json:"resources"`
+	Message   string   `json:"message,omitempty"`
+}
+
+// Summary counts workspaces by drift detection outcome
+type Summary struct {
+	Total   int `json:"total"`
+	Drifted int `json:"drifted"`
+	NoDrift int `json:"no-drift"`
+	Errored int `json:"errored"`
+	Skipped int `json:"skipped"`
+}
+
+// Report is the drift detection report of many workspaces
+type Report struct {
+	GeneratedAt time.Time `json:"generated-at"`
+	Summary     Summary   `json:"summary"`
+	Workspaces  []Result  `json:"workspaces"`
+}
+
+// NewReport returns report of the results sorted by workspace name
+func NewReport(results []Result, generatedAt time.Time) *Report {
+	report := &Report{GeneratedAt: generatedAt, Workspaces: make([]Result, len(results))}
+	copy(report.Workspaces, results)
+	sort.Slice(report.Workspaces, func(i, j int) bool { return report.Workspaces[i].Workspace < report.Workspaces[j].Workspace })
+
+	for i := range report.Workspaces {
+		result := &report.Workspaces[i]
+		if result.Resources == nil {
+			result.Resources = []string{}
+		}
+
+		report.Summary.Total++
+		switch result.Status {
+		case Drifted:
+			report.Summary.Drifted++
+		case NoDrift:
+			report.Summary.NoDrift++
+		case Errored:
+			report.Summary.Errored++
+		case Skipped:
+			report.Summary.Skipped++
+		}
+	}
+
+	return report
+}
+
+// String returns one line summary of the report
+func (s Summary) String() string {
+	return fmt.Sprintf("%d workspace(s) scanned: %d drifted, %d without drift, %d errored, %d skipped",
+		s.Total, s.Drifted, s.NoDrift, s.Errored, s.Skipped)
+}
+
+// WriteJSON writes the report as JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	return encoder.Encode(r)
+}
+
+// WriteMarkdown writes the report as Markdown with a summary table and drifted resources of each workspace
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("# Drift report\n\n")
+	fmt.Fprintf(&b, "%s (%s)\n\n", r.Summary, r.GeneratedAt.UTC().Format(time.RFC3339))
+	b.WriteString("| Workspace | Status | Drifted resources | Run | Message |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, result := range r.Workspaces {
+		run := result.RunID
+		if result.URL != "" {
+			run = fmt.Sprintf("[%s](%s)", result.RunID, result.URL)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %s |\n",
+			result.Workspace, result.Status, len(result.Resources), run, strings.ReplaceAll(result.Message, "|", "\\|"))
+	}
+
+	for _, result := range r.Workspaces {
+		if len(result.Resources) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", result.Workspace)
+		for _, address := range result.Resources {
+			fmt.Fprintf(&b, "- `%s`\n", address)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// junitTestSuites is the root element of JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML, every workspace is a test case failing when it has drifted
+func (r *Report) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:      "drift",
+		Tests:     r.Summary.Total,
+		Failures:  r.Summary.Drifted,
+		Errors:    r.Summary.Errored,
+		Skipped:   r.Summary.Skipped,
+		Timestamp: r.GeneratedAt.UTC().Format("2006-01-02T15:04:05"),
+	}
+	for _, result := range r.Workspaces {
+		testCase := junitTestCase{ClassName: "drift", Name: result.Workspace, SystemOut: result.URL}
+		switch result.Status {
+		case Drifted:
+			testCase.Failure = &junitMessage{
+				Message: fmt.Sprintf("%d resource(s) drifted", len(result.Resources)),
+				Body:    strings.Join(result.Resources, "\n"),
+			}
+		case Errored:
+			testCase.Error = &junitMessage{Message: result.Message}
+		case Skipped:
+			testCase.Skipped = &junitMessage{Message: result.Message}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{
+		Name:     "drift",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Suites:   []junitTestSuite{suite},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
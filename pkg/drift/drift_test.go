@@ -0,0 +1,101 @@
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testTime = time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+
+func testReport() *Report {
+	return NewReport([]Result{
+		{Workspace: "network", Status: NoDrift, Source: Assessment},
+		{Workspace: "app", Status: Drifted, Source: RefreshOnly, RunID: "run-1", URL: "https://app.terraform.io/app/org/workspaces/app/runs/run-1",
+			Resources: []string{"aws_instance.web", "aws_security_group.web"}},
+		{Workspace: "legacy", Status: Skipped, Message: "local execution mode"},
+		{Workspace: "cluster", Status: Errored, Message: "run errored"},
+	}, testTime)
+}
+
+func TestNewReport(t *testing.T) {
+	report := testReport()
+
+	want := Summary{Total: 4, Drifted: 1, NoDrift: 1, Errored: 1, Skipped: 1}
+	if report.Summary != want {
+		t.Errorf("NewReport() summary = %+v, want %+v", report.Summary, want)
+	}
+	if report.Workspaces[0].Workspace != "app" || report.Workspaces[3].Workspace != "network" {
+		t.Errorf("NewReport() workspaces are not sorted: %+v", report.Workspaces)
+	}
+	if report.Workspaces[3].Resources == nil {
+		t.Error("NewReport() resources should be empty list instead of nil")
+	}
+	if got := report.Summary.String(); got != "4 workspace(s) scanned: 1 drifted, 1 without drift, 1 errored, 1 skipped" {
+		t.Errorf("Summary.String() = %q", got)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() unexpected error: %v", err)
+	}
+
+	var got Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJSON() produced invalid JSON: %v", err)
+	}
+	if got.Summary.Drifted != 1 || len(got.Workspaces) != 4 || len(got.Workspaces[0].Resources) != 2 {
+		t.Errorf("WriteJSON() = %s", buf.String())
+	}
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown() unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"# Drift report",
+		"4 workspace(s) scanned: 1 drifted, 1 without drift, 1 errored, 1 skipped (2024-05-01T02:00:00Z)",
+		"| app | drifted | 2 | [run-1](https://app.terraform.io/app/org/workspaces/app/runs/run-1) |  |",
+		"| legacy | skipped | 0 |  | local execution mode |",
+		"## app\n\n- `aws_instance.web`\n- `aws_security_group.web`\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteMarkdown() output doesn't contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "## network") {
+		t.Error("WriteMarkdown() should list resources only for drifted workspaces")
+	}
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteJUnit(&buf); err != nil {
+		t.Fatalf("WriteJUnit() unexpected error: %v", err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("WriteJUnit() produced invalid XML: %v", err)
+	}
+	if got.Tests != 4 || got.Failures != 1 || got.Errors != 1 || got.Skipped != 1 || len(got.Suites) != 1 {
+		t.Fatalf("WriteJUnit() totals = %+v", got)
+	}
+
+	cases := got.Suites[0].Cases
+	if cases[0].Name != "app" || cases[0].Failure == nil || cases[0].Failure.Message != "2 resource(s) drifted" {
+		t.Errorf("WriteJUnit() drifted case = %+v", cases[0])
+	}
+	if cases[1].Error == nil || cases[2].Skipped == nil || cases[3].Failure != nil {
+		t.Errorf("WriteJUnit() cases = %+v", cases)
+	}
+}
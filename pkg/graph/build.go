@@ -0,0 +1,111 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ealebed/tfctl/pkg/worker"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// BuildOptions represents options for building the graph from Terraform API
+type BuildOptions struct {
+	// RemoteState adds remote state sharing edges in addition to run triggers
+	RemoteState bool
+	// Workers is the number of workspaces processed concurrently
+	Workers int
+}
+
+// Build returns dependency graph of the workspaces, workspaces outside of the list
+// are added when they are connected to any of the listed ones
+func Build(ctx context.Context, c *tfe.Client, workspaces []*tfe.Workspace, options BuildOptions) (*Graph, error) {
+	g := New()
+	var mu sync.Mutex
+
+	for _, workspace := range workspaces {
+		g.AddNode(workspace.Name)
+	}
+
+	errs := worker.Run(ctx, workspaces, worker.Options{Workers: options.Workers}, func(ctx context.Context, workspace *tfe.Workspace) error {
+		runTriggers, err := ListRunTriggers(ctx, c, workspace.ID, tfe.RunTriggerInbound)
+		if err != nil {
+			return err
+		}
+
+		var consumers []*tfe.Workspace
+		// Consumers are ignored while the state is shared globally
+		if options.RemoteState && !workspace.GlobalRemoteState {
+			if consumers, err = listRemoteStateConsumers(ctx, c, workspace.ID); err != nil {
+				return err
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, runTrigger := range runTriggers {
+			g.AddEdge(runTrigger.SourceableName, workspace.Name, RunTrigger)
+		}
+		for _, consumer := range consumers {
+			g.AddEdge(workspace.Name, consumer.Name, RemoteState)
+		}
+		return nil
+	})
+
+	return g, errors.Join(errs...)
+}
+
+// ListRunTriggers returns all the inbound (queueing runs in the workspace) or outbound
+// (queueing runs in other workspaces) run triggers of a workspace
+func ListRunTriggers(ctx context.Context, c *tfe.Client, workspaceID string, direction tfe.RunTriggerFilterOp) ([]*tfe.RunTrigger, error) {
+	var runTriggers []*tfe.RunTrigger
+
+	listOptions := &tfe.RunTriggerListOptions{ListOptions: tfe.ListOptions{PageSize: 100}, RunTriggerType: direction}
+	for {
+		runTriggerList, err := c.RunTriggers.List(ctx, workspaceID, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		runTriggers = append(runTriggers, runTriggerList.Items...)
+
+		if runTriggerList.Pagination == nil || runTriggerList.NextPage == 0 {
+			return runTriggers, nil
+		}
+		listOptions.PageNumber = runTriggerList.NextPage
+	}
+}
+
+// listRemoteStateConsumers returns all the workspaces allowed to read the state of a workspace
+func listRemoteStateConsumers(ctx context.Context, c *tfe.Client, workspaceID string) ([]*tfe.Workspace, error) {
+	var consumers []*tfe.Workspace
+
+	listOptions := &tfe.RemoteStateConsumersListOptions{ListOptions: tfe.ListOptions{PageSize: 100}}
+	for {
+		consumerList, err := c.Workspaces.ListRemoteStateConsumers(ctx, workspaceID, listOptions)
+		if err != nil {
+			return nil, err
+		}
+		consumers = append(consumers, consumerList.Items...)
+
+		if consumerList.Pagination == nil || consumerList.NextPage == 0 {
+			return consumers, nil
+		}
+		listOptions.PageNumber = consumerList.NextPage
+	}
+}
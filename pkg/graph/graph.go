@@ -0,0 +1,267 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package graph contains the dependency graph of workspaces built from run triggers and remote state sharing.
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EdgeKind is the kind of dependency between workspaces
+type EdgeKind string
+
+const (
+	// RunTrigger edge means a successful apply in the source workspace queues a run in the target one
+	RunTrigger EdgeKind = "run-trigger"
+	// RemoteState edge means the target workspace is allowed to read the state of the source one
+	RemoteState EdgeKind = "remote-state"
+	// Declared edge is a dependency declared in a dependency file
+	Declared EdgeKind = "declared"
+	// Transitive edge is a dependency through workspaces left out of a subgraph
+	Transitive EdgeKind = "transitive"
+)
+
+// Edge is a dependency of the To workspace on the From workspace
+type Edge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// Graph is a directed graph of workspace names
+type Graph struct {
+	nodes map[string]struct{}
+	edges map[Edge]struct{}
+}
+
+// New returns new empty graph
+func New() *Graph {
+	return &Graph{
+		nodes: map[string]struct{}{},
+		edges: map[Edge]struct{}{},
+	}
+}
+
+// AddNode adds the workspace to the graph
+func (g *Graph) AddNode(name string) {
+	g.nodes[name] = struct{}{}
+}
+
+// AddEdge adds the dependency with both of its workspaces to the graph
+func (g *Graph) AddEdge(from, to string, kind EdgeKind) {
+	g.AddNode(from)
+	g.AddNode(to)
+	g.edges[Edge{From: from, To: to, Kind: kind}] = struct{}{}
+}
+
+// Nodes returns sorted workspace names
+func (g *Graph) Nodes() []string {
+	nodes := make([]string, 0, len(g.nodes))
+	for node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Edges returns dependencies sorted by source, target and kind
+func (g *Graph) Edges() []Edge {
+	edges := make([]Edge, 0, len(g.edges))
+	for edge := range g.edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Kind < edges[j].Kind
+	})
+	return edges
+}
+
+// successors returns sorted unique targets of edges starting at the node
+func (g *Graph) successors() map[string][]string {
+	successors := map[string][]string{}
+	for _, edge := range g.Edges() {
+		targets := successors[edge.From]
+		if len(targets) == 0 || targets[len(targets)-1] != edge.To {
+			successors[edge.From] = append(targets, edge.To)
+		}
+	}
+	return successors
+}
+
+// Cycles returns groups of workspaces depending on each other (strongly connected components
+// with more than one workspace or with a self dependency), each group and the list are sorted
+func (g *Graph) Cycles() [][]string {
+	successors := g.successors()
+
+	// Tarjan's strongly connected components algorithm
+	index := 0
+	indexes := map[string]int{}
+	lowlinks := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var cycles [][]string
+
+	var connect func(node string)
+	connect = func(node string) {
+		indexes[node] = index
+		lowlinks[node] = index
+		index++
+		stack = append(stack, node)
+		onStack[node] = true
+
+		for _, next := range successors[node] {
+			if _, visited := indexes[next]; !visited {
+				connect(next)
+				lowlinks[node] = min(lowlinks[node], lowlinks[next])
+			} else if onStack[next] {
+				lowlinks[node] = min(lowlinks[node], indexes[next])
+			}
+		}
+
+		if lowlinks[node] != indexes[node] {
+			return
+		}
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == node {
+				break
+			}
+		}
+		if len(component) > 1 || contains(successors[node], node) {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, node := range g.Nodes() {
+		if _, visited := indexes[node]; !visited {
+			connect(node)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// inCycle returns function checking whether both workspaces of the edge belong to the same cycle
+func (g *Graph) inCycle() func(Edge) bool {
+	component := map[string]int{}
+	for i, cycle := range g.Cycles() {
+		for _, node := range cycle {
+			component[node] = i + 1
+		}
+	}
+	return func(edge Edge) bool {
+		return component[edge.From] != 0 && component[edge.From] == component[edge.To]
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	cycles := g.Cycles()
+	if cycles == nil {
+		cycles = [][]string{}
+	}
+	return json.Marshal(struct {
+		Nodes  []string   `json:"nodes"`
+		Edges  []Edge     `json:"edges"`
+		Cycles [][]string `json:"cycles"`
+	}{
+		Nodes:  g.Nodes(),
+		Edges:  g.Edges(),
+		Cycles: cycles,
+	})
+}
+
+// WriteDOT renders the graph in Graphviz DOT format, remote state edges are dashed and cycles are red
+func (g *Graph) WriteDOT(w io.Writer) error {
+	inCycle := g.inCycle()
+
+	var b strings.Builder
+	b.WriteString("digraph workspaces {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, node := range g.Nodes() {
+		fmt.Fprintf(&b, "  %q;\n", node)
+	}
+	for _, edge := range g.Edges() {
+		var attrs []string
+		if edge.Kind == RemoteState {
+			attrs = append(attrs, "style=dashed")
+		}
+		if inCycle(edge) {
+			attrs = append(attrs, "color=red")
+		}
+		attrs = append(attrs, fmt.Sprintf("label=%q", edge.Kind))
+		fmt.Fprintf(&b, "  %q -> %q [%s];\n", edge.From, edge.To, strings.Join(attrs, ", "))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders the graph as Mermaid flowchart, remote state edges are dotted and cycles are red
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	inCycle := g.inCycle()
+
+	ids := map[string]string{}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, node := range g.Nodes() {
+		ids[node] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node], strings.ReplaceAll(node, `"`, "#quot;"))
+	}
+
+	var cycleLinks []string
+	for i, edge := range g.Edges() {
+		arrow := "-->"
+		if edge.Kind == RemoteState {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", ids[edge.From], arrow, edge.Kind, ids[edge.To])
+		if inCycle(edge) {
+			cycleLinks = append(cycleLinks, fmt.Sprint(i))
+		}
+	}
+	if len(cycleLinks) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testGraph() *Graph {
+	g := New()
+	g.AddEdge("network", "cluster", RunTrigger)
+	g.AddEdge("cluster", "app", RunTrigger)
+	g.AddEdge("network", "app", RemoteState)
+	g.AddEdge("network", "app", RemoteState)
+	g.AddNode("standalone")
+	return g
+}
+
+func TestGraph_NodesEdges(t *testing.T) {
+	g := testGraph()
+
+	if got, want := g.Nodes(), []string{"app", "cluster", "network", "standalone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+
+	want := []Edge{
+		{From: "cluster", To: "app", Kind: RunTrigger},
+		{From: "network", To: "app", Kind: RemoteState},
+		{From: "network", To: "cluster", Kind: RunTrigger},
+	}
+	if got := g.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_Cycles(t *testing.T) {
+	g := testGraph()
+	if cycles := g.Cycles(); len(cycles) != 0 {
+		t.Errorf("Cycles() = %v, want none", cycles)
+	}
+
+	g.AddEdge("app", "network", RemoteState)
+	g.AddEdge("self", "self", RunTrigger)
+	want := [][]string{{"app", "cluster", "network"}, {"self"}}
+	if got := g.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := testGraph()
+	g.AddEdge("app", "cluster", RunTrigger)
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT() unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, line := range []string{
+		`"standalone";`,
+		`"network" -> "app" [style=dashed, label="remote-state"];`,
+		`"network" -> "cluster" [label="run-trigger"];`,
+		`"app" -> "cluster" [color=red, label="run-trigger"];`,
+		`"cluster" -> "app" [color=red, label="run-trigger"];`,
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("WriteDOT() output doesn't contain %q:\n%s", line, out)
+		}
+	}
+}
+
+func TestGraph_WriteMermaid(t *testing.T) {
+	g := testGraph()
+
+	var buf bytes.Buffer
+	if err := g.WriteMermaid(&buf); err != nil {
+		t.Fatalf("WriteMermaid() unexpected error: %v", err)
+	}
+
+	want := `flowchart LR
+  n0["app"]
+  n1["cluster"]
+  n2["network"]
+  n3["standalone"]
+  n1 -->|run-trigger| n0
+  n2 -.->|remote-state| n0
+  n2 -->|run-trigger| n1
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMermaid() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGraph_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(testGraph())
+	if err != nil {
+		t.Fatalf("MarshalJSON() unexpected error: %v", err)
+	}
+
+	var got struct {
+		Nodes  []string   `json:"nodes"`
+		Edges  []Edge     `json:"edges"`
+		Cycles [][]string `json:"cycles"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Nodes) != 4 || len(got.Edges) != 3 || got.Cycles == nil || len(got.Cycles) != 0 {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
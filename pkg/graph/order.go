@@ -0,0 +1,143 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Subgraph returns graph of the listed workspaces with dependencies between them. Workspaces
+// depending on each other only through workspaces which are not listed get a Transitive edge,
+// so the subgraph keeps the order of the full graph
+func (g *Graph) Subgraph(nodes []string) *Graph {
+	subgraph := New()
+	for _, node := range nodes {
+		subgraph.AddNode(node)
+	}
+	direct := map[[2]string]bool{}
+	for edge := range g.edges {
+		_, from := subgraph.nodes[edge.From]
+		_, to := subgraph.nodes[edge.To]
+		if from && to {
+			subgraph.edges[edge] = struct{}{}
+			direct[[2]string{edge.From, edge.To}] = true
+		}
+	}
+
+	// Walk from each listed workspace through the workspaces which are not listed
+	successors := g.successors()
+	for node := range subgraph.nodes {
+		visited := map[string]bool{node: true}
+		stack := []string{node}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, successor := range successors[current] {
+				if visited[successor] {
+					continue
+				}
+				visited[successor] = true
+				if _, listed := subgraph.nodes[successor]; !listed {
+					stack = append(stack, successor)
+				} else if current != node && !direct[[2]string{node, successor}] {
+					subgraph.AddEdge(node, successor, Transitive)
+				}
+			}
+		}
+	}
+
+	return subgraph
+}
+
+// Dependencies returns sorted unique workspaces the workspace depends on
+func (g *Graph) Dependencies(node string) []string {
+	var dependencies []string
+	for _, edge := range g.Edges() {
+		if edge.To == node && edge.From != node && !contains(dependencies, edge.From) {
+			dependencies = append(dependencies, edge.From)
+		}
+	}
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+// Levels returns workspaces in topological order grouped by levels: workspaces of a level
+// depend only on workspaces of previous levels, each level is sorted.
+// Returns error if the graph contains dependency cycles
+func (g *Graph) Levels() ([][]string, error) {
+	if cycles := g.Cycles(); len(cycles) > 0 {
+		groups := make([]string, 0, len(cycles))
+		for _, cycle := range cycles {
+			groups = append(groups, strings.Join(cycle, ", "))
+		}
+		return nil, fmt.Errorf("dependency cycle between workspaces: %s", strings.Join(groups, "; "))
+	}
+
+	// Kahn's algorithm processing all the workspaces without pending dependencies at once
+	inDegree := map[string]int{}
+	for node := range g.nodes {
+		inDegree[node] = len(g.Dependencies(node))
+	}
+	successors := g.successors()
+
+	var levels [][]string
+	var level []string
+	for node, degree := range inDegree {
+		if degree == 0 {
+			level = append(level, node)
+		}
+	}
+	for len(level) > 0 {
+		sort.Strings(level)
+		levels = append(levels, level)
+
+		var next []string
+		for _, node := range level {
+			for _, successor := range successors[node] {
+				inDegree[successor]--
+				if inDegree[successor] == 0 {
+					next = append(next, successor)
+				}
+			}
+		}
+		level = next
+	}
+
+	return levels, nil
+}
+
+// ParseDependencies returns graph of dependencies declared in JSON object mapping
+// workspace names to lists of workspaces they depend on, e.g.
+//
+//	{"cluster": ["network"], "app": ["network", "cluster"]}
+func ParseDependencies(data []byte) (*Graph, error) {
+	var dependencies map[string][]string
+	if err := json.Unmarshal(data, &dependencies); err != nil {
+		return nil, fmt.Errorf("invalid dependency file: %w", err)
+	}
+
+	g := New()
+	for workspace, workspaceDependencies := range dependencies {
+		g.AddNode(workspace)
+		for _, dependency := range workspaceDependencies {
+			g.AddEdge(dependency, workspace, Declared)
+		}
+	}
+	return g, nil
+}
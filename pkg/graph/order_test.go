@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGraph_Subgraph(t *testing.T) {
+	g := testGraph().Subgraph([]string{"app", "network", "missing"})
+
+	if got, want := g.Nodes(), []string{"app", "missing", "network"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+	if got, want := g.Edges(), []Edge{{From: "network", To: "app", Kind: RemoteState}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_Subgraph_Transitive(t *testing.T) {
+	g := New()
+	g.AddEdge("network", "clusters", RunTrigger)
+	g.AddEdge("clusters", "apps", RunTrigger)
+	g.AddEdge("apps", "monitoring", RunTrigger)
+	subgraph := g.Subgraph([]string{"network", "apps"})
+
+	if got, want := subgraph.Edges(), []Edge{{From: "network", To: "apps", Kind: Transitive}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+
+	want := [][]string{{"network"}, {"apps"}}
+	got, err := subgraph.Levels()
+	if err != nil {
+		t.Fatalf("Levels() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Levels() = %v, want %v", got, want)
+	}
+}
+
+func TestGraph_Dependencies(t *testing.T) {
+	g := testGraph()
+	g.AddEdge("app", "app", RunTrigger)
+
+	if got, want := g.Dependencies("app"), []string{"cluster", "network"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependencies(app) = %v, want %v", got, want)
+	}
+	if got := g.Dependencies("network"); len(got) != 0 {
+		t.Errorf("Dependencies(network) = %v, want none", got)
+	}
+}
+
+func TestGraph_Levels(t *testing.T) {
+	g := testGraph()
+	g.AddEdge("network", "db", RunTrigger)
+
+	want := [][]string{{"network", "standalone"}, {"cluster", "db"}, {"app"}}
+	got, err := g.Levels()
+	if err != nil {
+		t.Fatalf("Levels() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Levels() = %v, want %v", got, want)
+	}
+
+	g.AddEdge("app", "network", RunTrigger)
+	if _, err := g.Levels(); err == nil {
+		t.Error("Levels() with cycle error = nil, want error")
+	}
+}
+
+func TestParseDependencies(t *testing.T) {
+	g, err := ParseDependencies([]byte(`{"cluster": ["network"], "app": ["network", "cluster"], "standalone": []}`))
+	if err != nil {
+		t.Fatalf("ParseDependencies() error = %v", err)
+	}
+
+	want := []Edge{
+		{From: "cluster", To: "app", Kind: Declared},
+		{From: "network", To: "app", Kind: Declared},
+		{From: "network", To: "cluster", Kind: Declared},
+	}
+	if got := g.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Edges() = %v, want %v", got, want)
+	}
+	if got, want := g.Nodes(), []string{"app", "cluster", "network", "standalone"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Nodes() = %v, want %v", got, want)
+	}
+
+	if _, err := ParseDependencies([]byte(`["app"]`)); err == nil {
+		t.Error("ParseDependencies() of list error = nil, want error")
+	}
+}
@@ -18,7 +18,10 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/hashicorp/go-tfe"
 )
@@ -49,6 +52,23 @@ type outputPolicySet struct {
 	WorkspaceCount int          `jsonapi:"attr,workspace-count"`
 }
 
+// Take needed fields from https://pkg.go.dev/github.com/hashicorp/go-tfe#Project
+type outputProject struct {
+	ID          string `jsonapi:"primary,projects"`
+	Name        string `jsonapi:"attr,name"`
+	Description string `jsonapi:"attr,description"`
+}
+
+// Take needed fields from https://pkg.go.dev/github.com/hashicorp/go-tfe#RunTask
+type outputRunTask struct {
+	ID          string `jsonapi:"primary,tasks"`
+	Name        string `jsonapi:"attr,name"`
+	URL         string `jsonapi:"attr,url"`
+	Description string `jsonapi:"attr,description"`
+	Category    string `jsonapi:"attr,category"`
+	Enabled     bool   `jsonapi:"attr,enabled"`
+}
+
 // Take needed fields from https://pkg.go.dev/github.com/hashicorp/go-tfe@v1.1.0#Variable
 type outputVariable struct {
 	ID          string           `jsonapi:"primary,vars"`
@@ -62,12 +82,13 @@ type outputVariable struct {
 
 // Take needed fields from https://pkg.go.dev/github.com/hashicorp/go-tfe@v1.1.0#Workspace
 type outputWorkspace struct {
-	ID               string   `jsonapi:"primary,workspaces"`
-	Description      string   `jsonapi:"attr,description"`
-	ExecutionMode    string   `jsonapi:"attr,execution-mode"`
-	Name             string   `jsonapi:"attr,name"`
-	TerraformVersion string   `jsonapi:"attr,terraform-version"`
-	TagNames         []string `jsonapi:"attr,tag-names"`
+	ID               string         `jsonapi:"primary,workspaces"`
+	Description      string         `jsonapi:"attr,description"`
+	ExecutionMode    string         `jsonapi:"attr,execution-mode"`
+	Name             string         `jsonapi:"attr,name"`
+	TerraformVersion string         `jsonapi:"attr,terraform-version"`
+	TagNames         []string       `jsonapi:"attr,tag-names"`
+	Project          *outputProject `jsonapi:"relation,project"`
 }
 
 func marshalToJson(input interface{}) ([]byte, error) {
@@ -117,6 +138,20 @@ func JsonPrettyOutput(input interface{}, inputType string) {
 			os.Exit(1)
 		}
 		JsonOutput(out)
+	case "project":
+		var out *outputProject
+		if err := json.Unmarshal(tmp, &out); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+			os.Exit(1)
+		}
+		JsonOutput(out)
+	case "runTask":
+		var out *outputRunTask
+		if err := json.Unmarshal(tmp, &out); err != nil {
+			fmt.Fprintf(os.Stderr, "\n%v\n", err)
+			os.Exit(1)
+		}
+		JsonOutput(out)
 	case "OAuthClient":
 		var out *outputOAuthClient
 		if err := json.Unmarshal(tmp, &out); err != nil {
@@ -129,3 +164,27 @@ func JsonPrettyOutput(input interface{}, inputType string) {
 		os.Exit(1)
 	}
 }
+
+// TableOutput prints rows aligned in columns under given headers
+func TableOutput(headers []string, rows [][]string) {
+	TableOutputTo(os.Stdout, headers, rows)
+}
+
+// TableOutputTo writes rows aligned in columns under given headers to w
+func TableOutputTo(w io.Writer, headers []string, rows [][]string) {
+	if err := writeTable(w, headers, rows); err != nil {
+		fmt.Fprintf(os.Stderr, "\n%v\n", err)
+		os.Exit(1)
+	}
+}
+
+func writeTable(w io.Writer, headers []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"testing"
 )
@@ -172,3 +173,33 @@ func TestJsonOutput(t *testing.T) {
 	// but we can verify it doesn't crash
 	JsonOutput(input)
 }
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeTable(&buf, []string{"NAME", "STATUS"}, [][]string{
+		{"workspace-one", "applied"},
+		{"ws-2", "errored"},
+	})
+	if err != nil {
+		t.Fatalf("writeTable() unexpected error: %v", err)
+	}
+
+	want := "NAME           STATUS\n" +
+		"workspace-one  applied\n" +
+		"ws-2           errored\n"
+	if buf.String() != want {
+		t.Errorf("writeTable() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteTable_NoRows(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := writeTable(&buf, []string{"NAME", "STATUS"}, nil); err != nil {
+		t.Fatalf("writeTable() unexpected error: %v", err)
+	}
+	if buf.String() != "NAME  STATUS\n" {
+		t.Errorf("writeTable() = %q, want only headers", buf.String())
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RedactedValue replaces values of sensitive outputs which were not requested explicitly in human readable output
+const RedactedValue = "(sensitive)"
+
+// NamedValue represents a terraform output value with its name
+type NamedValue struct {
+	Name  string
+	Value interface{}
+	// Redacted value is omitted in JSON and replaced with a comment in other formats
+	Redacted bool
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// FormatValues renders named values in one of the 'json', 'env', 'dotenv' or 'tfvars' formats
+func FormatValues(values []NamedValue, format string) (string, error) {
+	sorted := make([]NamedValue, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	switch format {
+	case "json":
+		object := make(map[string]interface{}, len(sorted))
+		for _, v := range sorted {
+			if !v.Redacted {
+				object[v.Name] = v.Value
+			}
+		}
+		res, err := marshalToJson(object)
+		if err != nil {
+			return "", err
+		}
+		b.Write(res)
+		b.WriteString("\n")
+	case "env":
+		for _, v := range sorted {
+			if v.Redacted {
+				writeRedacted(&b, EnvName(v.Name))
+				continue
+			}
+			value, err := FormatRaw(v.Value)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "export %s=%s\n", EnvName(v.Name), shellQuote(value))
+		}
+	case "dotenv":
+		for _, v := range sorted {
+			if v.Redacted {
+				writeRedacted(&b, EnvName(v.Name))
+				continue
+			}
+			value, err := FormatRaw(v.Value)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "%s=%s\n", EnvName(v.Name), strconv.Quote(value))
+		}
+	case "tfvars":
+		for _, v := range sorted {
+			if v.Redacted {
+				writeRedacted(&b, v.Name)
+				continue
+			}
+			fmt.Fprintf(&b, "%s = %s\n", v.Name, hclValue(v.Value))
+		}
+	default:
+		return "", fmt.Errorf("unsupported output format '%s', use 'json', 'env', 'dotenv' or 'tfvars'", format)
+	}
+
+	return b.String(), nil
+}
+
+// writeRedacted writes comment instead of the redacted value, so scripts never read a placeholder as the real value.
+// JSON has no comments, so redacted values are omitted there completely
+func writeRedacted(b *strings.Builder, name string) {
+	fmt.Fprintf(b, "# %s is sensitive and omitted\n", name)
+}
+
+// FormatRaw renders a single value: strings as is, other types as compact JSON
+func FormatRaw(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	res, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal to json: %v", err)
+	}
+
+	return string(res), nil
+}
+
+// EnvName converts output name to upper-cased environment variable name
+func EnvName(name string) string {
+	return strings.ToUpper(nonIdentifierChars.ReplaceAllString(name, "_"))
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// hclValue renders a JSON-decoded value in HCL syntax
+func hclValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		quoted := strconv.Quote(v)
+		quoted = strings.ReplaceAll(quoted, "${", "$${")
+		return strings.ReplaceAll(quoted, "%{", "%%{")
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, hclValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, 0, len(v))
+		for _, key := range keys {
+			items = append(items, strconv.Quote(key)+" = "+hclValue(v[key]))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		raw, _ := FormatRaw(v)
+		return raw
+	}
+}
@@ -0,0 +1,131 @@
+package output
+
+import (
+	"testing"
+)
+
+func TestFormatValues(t *testing.T) {
+	values := []NamedValue{
+		{Name: "vpc_id", Value: "vpc-123"},
+		{Name: "instance-count", Value: float64(3)},
+		{Name: "zones", Value: []interface{}{"a", "b"}},
+		{Name: "quote", Value: "it's ${here}"},
+		{Name: "db_password", Value: RedactedValue, Redacted: true},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "json",
+			format: "json",
+			want: "{\n" +
+				" \"instance-count\": 3,\n" +
+				" \"quote\": \"it's ${here}\",\n" +
+				" \"vpc_id\": \"vpc-123\",\n" +
+				" \"zones\": [\n  \"a\",\n  \"b\"\n ]\n" +
+				"}\n",
+		},
+		{
+			name:   "env",
+			format: "env",
+			want: "# DB_PASSWORD is sensitive and omitted\n" +
+				"export INSTANCE_COUNT='3'\n" +
+				"export QUOTE='it'\\''s ${here}'\n" +
+				"export VPC_ID='vpc-123'\n" +
+				"export ZONES='[\"a\",\"b\"]'\n",
+		},
+		{
+			name:   "dotenv",
+			format: "dotenv",
+			want: "# DB_PASSWORD is sensitive and omitted\n" +
+				"INSTANCE_COUNT=\"3\"\n" +
+				"QUOTE=\"it's ${here}\"\n" +
+				"VPC_ID=\"vpc-123\"\n" +
+				"ZONES=\"[\\\"a\\\",\\\"b\\\"]\"\n",
+		},
+		{
+			name:   "tfvars",
+			format: "tfvars",
+			want: "# db_password is sensitive and omitted\n" +
+				"instance-count = 3\n" +
+				"quote = \"it's $${here}\"\n" +
+				"vpc_id = \"vpc-123\"\n" +
+				"zones = [\"a\", \"b\"]\n",
+		},
+		{
+			name:    "unsupported format",
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatValues(values, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FormatValues() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRaw(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{name: "string", value: "vpc-123", want: "vpc-123"},
+		{name: "number", value: float64(1.5), want: "1.5"},
+		{name: "bool", value: true, want: "true"},
+		{name: "null", value: nil, want: "null"},
+		{name: "map", value: map[string]interface{}{"a": "b"}, want: `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatRaw(tt.value)
+			if err != nil {
+				t.Fatalf("FormatRaw() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatRaw() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHclValue(t *testing.T) {
+	value := map[string]interface{}{
+		"name":    "app",
+		"enabled": false,
+		"tags":    []interface{}{"prod", nil},
+		"nested":  map[string]interface{}{"tpl": "%{if}"},
+	}
+
+	want := `{"enabled" = false, "name" = "app", "nested" = {"tpl" = "%%{if}"}, "tags" = ["prod", null]}`
+	if got := hclValue(value); got != want {
+		t.Errorf("hclValue() = %s, want %s", got, want)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := map[string]string{
+		"vpc_id":         "VPC_ID",
+		"instance-count": "INSTANCE_COUNT",
+		"a.b":            "A_B",
+	}
+
+	for name, want := range tests {
+		if got := EnvName(name); got != want {
+			t.Errorf("EnvName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planjson contains helpers for reading terraform JSON execution plans.
+package planjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Action is a summarized action planned for a resource
+type Action string
+
+// Actions planned for resources
+const (
+	Create  Action = "create"
+	Update  Action = "update"
+	Delete  Action = "delete"
+	Replace Action = "replace"
+	Read    Action = "read"
+	NoOp    Action = "no-op"
+)
+
+// Actions lists summarized actions in the order they are shown
+var Actions = []Action{Create, Update, Replace, Delete, Read, NoOp}
+
+// Plan represents the part of terraform JSON execution plan describing resource changes
+type Plan struct {
+	FormatVersion   string           `json:"format_version"`
+	ResourceChanges []ResourceChange `json:"resource_changes"`
+	// ResourceDrift lists resources changed outside of terraform since the last apply
+	ResourceDrift []ResourceChange `json:"resource_drift"`
+}
+
+// ResourceChange represents planned change of a single resource instance
+type ResourceChange struct {
+	Address string `json:"address"`
+	Change  Change `json:"change"`
+}
+
+// Change represents actions planned for a resource instance
+type Change struct {
+	Actions []string `json:"actions"`
+}
+
+// Summary maps summarized actions to sorted addresses of the resources
+type Summary map[Action][]string
+
+// Parse parses terraform JSON execution plan
+func Parse(data []byte) (*Plan, error) {
+	var plan Plan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON plan: %v", err)
+	}
+
+	return &plan, nil
+}
+
+// Action returns summarized action of the resource change, create and delete
+// of the same resource in any order is a replace
+func (rc ResourceChange) Action() Action {
+	actions := rc.Change.Actions
+
+	switch {
+	case len(actions) == 2 && actions[0] != actions[1]:
+		return Replace
+	case len(actions) == 1:
+		return Action(actions[0])
+	default:
+		return NoOp
+	}
+}
+
+// Summarize groups addresses of changed resources by summarized action
+func (p *Plan) Summarize() Summary {
+	summary := Summary{}
+
+	for _, rc := range p.ResourceChanges {
+		action := rc.Action()
+		summary[action] = append(summary[action], rc.Address)
+	}
+	for _, addresses := range summary {
+		sort.Strings(addresses)
+	}
+
+	return summary
+}
+
+// Drifted returns sorted addresses of the resources changed outside of terraform
+func (p *Plan) Drifted() []string {
+	var addresses []string
+	for _, rc := range p.ResourceDrift {
+		if rc.Action() != NoOp {
+			addresses = append(addresses, rc.Address)
+		}
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
+
+// Destroys returns number of resources which are going to be destroyed, including replaced ones
+func (s Summary) Destroys() int {
+	return len(s[Delete]) + len(s[Replace])
+}
@@ -0,0 +1,108 @@
+package planjson
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testPlan = `{
+  "format_version": "1.2",
+  "resource_changes": [
+    {"address": "aws_instance.web", "change": {"actions": ["create"]}},
+    {"address": "aws_s3_bucket.logs", "change": {"actions": ["update"]}},
+    {"address": "aws_db_instance.main", "change": {"actions": ["delete", "create"]}},
+    {"address": "aws_eip.web", "change": {"actions": ["create", "delete"]}},
+    {"address": "aws_iam_role.old", "change": {"actions": ["delete"]}},
+    {"address": "data.aws_ami.ubuntu", "change": {"actions": ["read"]}},
+    {"address": "aws_vpc.main", "change": {"actions": ["no-op"]}},
+    {"address": "aws_instance.api", "change": {"actions": ["create"]}}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	plan, err := Parse([]byte(testPlan))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if plan.FormatVersion != "1.2" {
+		t.Errorf("Parse() format version = %q, want %q", plan.FormatVersion, "1.2")
+	}
+	if len(plan.ResourceChanges) != 8 {
+		t.Errorf("Parse() resource changes = %d, want 8", len(plan.ResourceChanges))
+	}
+
+	if _, err := Parse([]byte("not json")); err == nil {
+		t.Error("Parse() should return error for invalid JSON")
+	}
+}
+
+func TestResourceChangeAction(t *testing.T) {
+	tests := []struct {
+		actions []string
+		want    Action
+	}{
+		{actions: []string{"create"}, want: Create},
+		{actions: []string{"update"}, want: Update},
+		{actions: []string{"delete"}, want: Delete},
+		{actions: []string{"delete", "create"}, want: Replace},
+		{actions: []string{"create", "delete"}, want: Replace},
+		{actions: []string{"read"}, want: Read},
+		{actions: []string{"no-op"}, want: NoOp},
+		{actions: nil, want: NoOp},
+	}
+
+	for _, tt := range tests {
+		rc := ResourceChange{Change: Change{Actions: tt.actions}}
+		if got := rc.Action(); got != tt.want {
+			t.Errorf("Action(%v) = %q, want %q", tt.actions, got, tt.want)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	plan, err := Parse([]byte(testPlan))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	want := Summary{
+		Create:  {"aws_instance.api", "aws_instance.web"},
+		Update:  {"aws_s3_bucket.logs"},
+		Replace: {"aws_db_instance.main", "aws_eip.web"},
+		Delete:  {"aws_iam_role.old"},
+		Read:    {"data.aws_ami.ubuntu"},
+		NoOp:    {"aws_vpc.main"},
+	}
+
+	summary := plan.Summarize()
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Summarize() = %v, want %v", summary, want)
+	}
+	if got := summary.Destroys(); got != 3 {
+		t.Errorf("Destroys() = %d, want 3", got)
+	}
+}
+
+func TestDrifted(t *testing.T) {
+	plan, err := Parse([]byte(`{
+  "format_version": "1.2",
+  "resource_drift": [
+    {"address": "aws_security_group.web", "change": {"actions": ["update"]}},
+    {"address": "aws_instance.web", "change": {"actions": ["delete"]}},
+    {"address": "aws_vpc.main", "change": {"actions": ["no-op"]}}
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	want := []string{"aws_instance.web", "aws_security_group.web"}
+	if got := plan.Drifted(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Drifted() = %v, want %v", got, want)
+	}
+
+	plan, _ = Parse([]byte(testPlan))
+	if got := plan.Drifted(); got != nil {
+		t.Errorf("Drifted() = %v, want none", got)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy contains helpers for reading policy check results of terraform runs.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	// Advisory policies are allowed to fail
+	Advisory = "advisory"
+	// Mandatory policies stop the run when failed (soft-mandatory ones can be overridden)
+	Mandatory = "mandatory"
+)
+
+// Result represents result of a single Sentinel policy
+type Result struct {
+	PolicySet   string `json:"policy-set"`
+	Policy      string `json:"policy"`
+	Enforcement string `json:"enforcement"`
+	Passed      bool   `json:"passed"`
+	Error       string `json:"error,omitempty"`
+}
+
+// sentinelResult is the 'sentinel' attribute of policy check result
+type sentinelResult struct {
+	Data map[string]struct {
+		Policies []struct {
+			AllowedFailure bool   `json:"allowed-failure"`
+			Error          any    `json:"error"`
+			Policy         string `json:"policy"`
+			Result         bool   `json:"result"`
+		} `json:"policies"`
+	} `json:"data"`
+}
+
+// ParseSentinel returns per-policy results from the Sentinel result of a policy check,
+// sorted by policy set and policy name. Sentinel result tells only whether the policy
+// is allowed to fail, so enforcement is either advisory or mandatory.
+func ParseSentinel(sentinel any) ([]Result, error) {
+	if sentinel == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(sentinel)
+	if err != nil {
+		return nil, err
+	}
+	var result sentinelResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sentinel result: %v", err)
+	}
+
+	var results []Result
+	for policySet, set := range result.Data {
+		for _, policy := range set.Policies {
+			item := Result{
+				PolicySet:   policySet,
+				Policy:      policy.Policy,
+				Enforcement: Mandatory,
+				Passed:      policy.Result,
+			}
+			if policy.AllowedFailure {
+				item.Enforcement = Advisory
+			}
+			if policy.Error != nil {
+				item.Error = fmt.Sprint(policy.Error)
+			}
+			results = append(results, item)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].PolicySet != results[j].PolicySet {
+			return results[i].PolicySet < results[j].PolicySet
+		}
+		return results[i].Policy < results[j].Policy
+	})
+
+	return results, nil
+}
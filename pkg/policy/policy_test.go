@@ -0,0 +1,59 @@
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseSentinel(t *testing.T) {
+	data := `{
+  "schema-version": "1.0.0",
+  "data": {
+    "networking": {
+      "can-override": true,
+      "error": null,
+      "policies": [
+        {"allowed-failure": false, "error": null, "policy": "networking/only-one-subnet", "result": false, "trace": {}},
+        {"allowed-failure": true, "error": null, "policy": "networking/cidr-size", "result": true, "trace": {}}
+      ],
+      "result": false
+    },
+    "cost": {
+      "can-override": false,
+      "error": null,
+      "policies": [
+        {"allowed-failure": false, "error": "import failed", "policy": "cost/limit", "result": false}
+      ],
+      "result": false
+    }
+  }
+}`
+	// Sentinel result is decoded by go-tfe as a generic value
+	var sentinel any
+	if err := json.Unmarshal([]byte(data), &sentinel); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := ParseSentinel(sentinel)
+	if err != nil {
+		t.Fatalf("ParseSentinel() unexpected error: %v", err)
+	}
+
+	want := []Result{
+		{PolicySet: "cost", Policy: "cost/limit", Enforcement: Mandatory, Error: "import failed"},
+		{PolicySet: "networking", Policy: "networking/cidr-size", Enforcement: Advisory, Passed: true},
+		{PolicySet: "networking", Policy: "networking/only-one-subnet", Enforcement: Mandatory},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("ParseSentinel() = %d results, want %d", len(results), len(want))
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("ParseSentinel()[%d] = %+v, want %+v", i, results[i], want[i])
+		}
+	}
+
+	if results, err := ParseSentinel(nil); err != nil || results != nil {
+		t.Errorf("ParseSentinel(nil) = %v, %v, want nil, nil", results, err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runlog contains helpers for printing terraform plan and apply logs.
+package runlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// maxLineSize is the maximum size of a single log line
+const maxLineSize = 1024 * 1024
+
+// ansiEscape matches ANSI escape sequences used for terminal colors
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// Options represents options for copying a log
+type Options struct {
+	// StripColors removes ANSI escape sequences from the log lines
+	StripColors bool
+	// JSON receives structured JSON log lines as is, not written if nil
+	JSON io.Writer
+}
+
+// structuredLine represents a line of terraform structured (JSON) log
+type structuredLine struct {
+	Message string `json:"@message"`
+}
+
+// StripANSI removes ANSI escape sequences from the string
+func StripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
+
+// Copy copies the log from src to dst line by line. Structured JSON log lines are
+// written to dst as their human readable '@message' and to options.JSON as is.
+func Copy(dst io.Writer, src io.Reader, options Options) error {
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if message, ok := parseStructured(line); ok {
+			if options.JSON != nil {
+				if _, err := fmt.Fprintln(options.JSON, line); err != nil {
+					return err
+				}
+			}
+			line = message
+		}
+
+		if options.StripColors {
+			line = StripANSI(line)
+		}
+		if _, err := fmt.Fprintln(dst, line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseStructured returns the human readable message of a structured log line
+func parseStructured(line string) (string, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return "", false
+	}
+
+	var structured structuredLine
+	if err := json.Unmarshal([]byte(line), &structured); err != nil || structured.Message == "" {
+		return "", false
+	}
+
+	return structured.Message, true
+}
@@ -0,0 +1,80 @@
+package runlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "no colors", input: "Plan: 1 to add", want: "Plan: 1 to add"},
+		{name: "bold and reset", input: "\x1b[1mPlan:\x1b[0m 1 to add", want: "Plan: 1 to add"},
+		{name: "color with params", input: "\x1b[32;1m+\x1b[0m create", want: "+ create"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.input); got != tt.want {
+				t.Errorf("StripANSI() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	log := "Terraform v1.9.5\n" +
+		"{\"@level\":\"info\",\"@message\":\"aws_instance.web: Plan to create\",\"type\":\"planned_change\"}\n" +
+		"\x1b[1mPlan:\x1b[0m 1 to add\n" +
+		"{not json}\n"
+
+	tests := []struct {
+		name     string
+		options  Options
+		withJSON bool
+		want     string
+		wantJSON string
+	}{
+		{
+			name: "preserve colors",
+			want: "Terraform v1.9.5\n" +
+				"aws_instance.web: Plan to create\n" +
+				"\x1b[1mPlan:\x1b[0m 1 to add\n" +
+				"{not json}\n",
+		},
+		{
+			name:     "strip colors and write structured lines",
+			options:  Options{StripColors: true},
+			withJSON: true,
+			want: "Terraform v1.9.5\n" +
+				"aws_instance.web: Plan to create\n" +
+				"Plan: 1 to add\n" +
+				"{not json}\n",
+			wantJSON: "{\"@level\":\"info\",\"@message\":\"aws_instance.web: Plan to create\",\"type\":\"planned_change\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, jsonOut bytes.Buffer
+			options := tt.options
+			if tt.withJSON {
+				options.JSON = &jsonOut
+			}
+
+			if err := Copy(&out, strings.NewReader(log), options); err != nil {
+				t.Fatalf("Copy() unexpected error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Copy() = %q, want %q", out.String(), tt.want)
+			}
+			if jsonOut.String() != tt.wantJSON {
+				t.Errorf("Copy() JSON = %q, want %q", jsonOut.String(), tt.wantJSON)
+			}
+		})
+	}
+}
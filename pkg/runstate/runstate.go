@@ -0,0 +1,343 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runstate contains helpers for inspecting and waiting on terraform runs.
+package runstate
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// DefaultInterval is the default delay between two run status reads
+const DefaultInterval = 5 * time.Second
+
+// backoffFactor is the growth of the delay between two reads of a run which status doesn't change
+const backoffFactor = 1.5
+
+// Exit codes describing the outcome of a run
+const (
+	ExitApplied          = 0
+	ExitError            = 1
+	ExitPlannedChanges   = 2
+	ExitPlannedNoChanges = 3
+	ExitErrored          = 4
+	ExitDiscarded        = 5
+	ExitPolicyFailed     = 6
+	ExitCanceled         = 7
+	ExitTimeout          = 8
+)
+
+// WaitOptions represents options for waiting on a run
+type WaitOptions struct {
+	// Interval between two run status reads, DefaultInterval if not set
+	Interval time.Duration
+	// MaxInterval enables backoff: while the run status doesn't change the interval
+	// grows up to MaxInterval. No backoff if it is not greater than Interval
+	MaxInterval time.Duration
+	// UntilFinal keeps waiting while the run needs an action from the user
+	UntilFinal bool
+	// OnStatus is called every time the run status changes
+	OnStatus func(run *tfe.Run)
+}
+
+// URL returns link to the run page in Terraform Enterprise (Cloud) UI
+func URL(hostname, organization, workspace, runID string) string {
+	return fmt.Sprintf("https://%s/app/%s/workspaces/%s/runs/%s",
+		hostname, url.PathEscape(organization), url.PathEscape(workspace), url.PathEscape(runID))
+}
+
+// PlanSummary returns human readable summary of resource changes proposed by the plan
+func PlanSummary(plan *tfe.Plan) string {
+	if plan == nil {
+		return "unknown"
+	}
+	if !plan.HasChanges && plan.ResourceAdditions+plan.ResourceChanges+plan.ResourceDestructions+plan.ResourceImports == 0 {
+		return "no changes"
+	}
+
+	summary := fmt.Sprintf("%d to add, %d to change, %d to destroy", plan.ResourceAdditions, plan.ResourceChanges, plan.ResourceDestructions)
+	if plan.ResourceImports > 0 {
+		summary = fmt.Sprintf("%d to import, %s", plan.ResourceImports, summary)
+	}
+
+	return summary
+}
+
+// FinishedAt returns time of the latest status change of the run or zero time if it is not finished yet
+func FinishedAt(run *tfe.Run) time.Time {
+	if run == nil || run.StatusTimestamps == nil || !IsFinal(run.Status) {
+		return time.Time{}
+	}
+
+	ts := run.StatusTimestamps
+	var latest time.Time
+	for _, t := range []time.Time{
+		ts.AppliedAt, ts.CanceledAt, ts.DiscardedAt, ts.ErroredAt, ts.ForceCanceledAt,
+		ts.PlannedAndFinishedAt, ts.PlannedAndSavedAt,
+	} {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// Duration returns how long the run took or is running for if it is not finished yet
+func Duration(run *tfe.Run, now time.Time) time.Duration {
+	if run == nil || run.CreatedAt.IsZero() {
+		return 0
+	}
+
+	end := FinishedAt(run)
+	if end.IsZero() {
+		if IsFinal(run.Status) {
+			return 0
+		}
+		end = now
+	}
+
+	return end.Sub(run.CreatedAt).Round(time.Second)
+}
+
+// StatusChange is a status the run reached at the given time
+type StatusChange struct {
+	Status tfe.RunStatus `json:"status"`
+	At     time.Time     `json:"at"`
+}
+
+// Timeline returns statuses the run went through ordered by time, starting with its creation
+func Timeline(run *tfe.Run) []StatusChange {
+	if run == nil {
+		return nil
+	}
+
+	var timeline []StatusChange
+	if !run.CreatedAt.IsZero() {
+		timeline = append(timeline, StatusChange{Status: tfe.RunPending, At: run.CreatedAt})
+	}
+	if run.StatusTimestamps == nil {
+		return timeline
+	}
+
+	ts := run.StatusTimestamps
+	for _, change := range []StatusChange{
+		{Status: tfe.RunPlanQueued, At: ts.PlanQueuedAt},
+		{Status: tfe.RunFetching, At: ts.FetchingAt},
+		{Status: tfe.RunFetchingCompleted, At: ts.FetchedAt},
+		{Status: tfe.RunPrePlanRunning, At: ts.PrePlanRunningAt},
+		{Status: tfe.RunPrePlanCompleted, At: ts.PrePlanCompletedAt},
+		{Status: tfe.RunPlanning, At: ts.PlanningAt},
+		{Status: tfe.RunPlanned, At: ts.PlannedAt},
+		{Status: tfe.RunPlannedAndFinished, At: ts.PlannedAndFinishedAt},
+		{Status: tfe.RunPlannedAndSaved, At: ts.PlannedAndSavedAt},
+		{Status: tfe.RunCostEstimating, At: ts.CostEstimatingAt},
+		{Status: tfe.RunCostEstimated, At: ts.CostEstimatedAt},
+		{Status: tfe.RunPolicyChecked, At: ts.PolicyCheckedAt},
+		{Status: tfe.RunPolicySoftFailed, At: ts.PolicySoftFailedAt},
+		{Status: tfe.RunPostPlanRunning, At: ts.PostPlanRunningAt},
+		{Status: tfe.RunPostPlanCompleted, At: ts.PostPlanCompletedAt},
+		{Status: tfe.RunConfirmed, At: ts.ConfirmedAt},
+		{Status: tfe.RunApplyQueued, At: ts.ApplyQueuedAt},
+		{Status: tfe.RunApplying, At: ts.ApplyingAt},
+		{Status: tfe.RunApplied, At: ts.AppliedAt},
+		{Status: tfe.RunDiscarded, At: ts.DiscardedAt},
+		{Status: tfe.RunErrored, At: ts.ErroredAt},
+		{Status: tfe.RunCanceled, At: ts.CanceledAt},
+	} {
+		if !change.At.IsZero() {
+			timeline = append(timeline, change)
+		}
+	}
+
+	// Stable sort keeps the order of statuses reached at the same second
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].At.Before(timeline[j].At) })
+
+	return timeline
+}
+
+// IsWaiting returns true if the run waits in the queue for its turn in the workspace or for a free worker
+func IsWaiting(status tfe.RunStatus) bool {
+	switch status {
+	case tfe.RunPending,
+		tfe.RunPlanQueued,
+		tfe.RunQueuing,
+		tfe.RunApplyQueued,
+		tfe.RunQueuingApply:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitingSince returns time the run entered the queue or zero time if it is not waiting
+func WaitingSince(run *tfe.Run) time.Time {
+	if run == nil || !IsWaiting(run.Status) {
+		return time.Time{}
+	}
+
+	var candidates []time.Time
+	if ts := run.StatusTimestamps; ts != nil {
+		switch run.Status {
+		case tfe.RunPlanQueued, tfe.RunQueuing:
+			candidates = []time.Time{ts.PlanQueuedAt, ts.QueuingAt}
+		case tfe.RunApplyQueued, tfe.RunQueuingApply:
+			candidates = []time.Time{ts.ApplyQueuedAt, ts.ConfirmedAt}
+		}
+	}
+	for _, t := range candidates {
+		if !t.IsZero() {
+			return t
+		}
+	}
+
+	return run.CreatedAt
+}
+
+// IsFinal returns true if the run reached a status it will never leave
+func IsFinal(status tfe.RunStatus) bool {
+	switch status {
+	case tfe.RunApplied,
+		tfe.RunPlannedAndFinished,
+		tfe.RunPlannedAndSaved,
+		tfe.RunErrored,
+		tfe.RunDiscarded,
+		tfe.RunCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsAction returns true if the run is paused until somebody confirms, discards or overrides it
+func NeedsAction(run *tfe.Run) bool {
+	if run == nil {
+		return false
+	}
+
+	switch run.Status {
+	case tfe.RunPolicySoftFailed, tfe.RunPostPlanAwaitingDecision:
+		return true
+	}
+
+	return run.Actions != nil && run.Actions.IsConfirmable && !run.AutoApply
+}
+
+// Wait polls the run until it reaches a final status or needs an action from the user
+func Wait(ctx context.Context, c *tfe.Client, runID string, options WaitOptions) (*tfe.Run, error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	delay := interval
+	var lastStatus tfe.RunStatus
+	for {
+		run, err := c.Runs.Read(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+
+		changed := run.Status != lastStatus
+		if changed {
+			lastStatus = run.Status
+			if options.OnStatus != nil {
+				options.OnStatus(run)
+			}
+		}
+
+		if IsFinal(run.Status) || (NeedsAction(run) && !options.UntilFinal) {
+			return run, nil
+		}
+
+		delay = nextInterval(delay, interval, options.MaxInterval, changed)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return run, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Stop discards the run if it waits for confirmation or cancels it if it is still in progress,
+// runs which can be neither discarded nor canceled are left as they are
+func Stop(ctx context.Context, c *tfe.Client, runID, comment string) error {
+	run, err := c.Runs.Read(ctx, runID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case run.Actions != nil && run.Actions.IsDiscardable:
+		return c.Runs.Discard(ctx, runID, tfe.RunDiscardOptions{Comment: tfe.String(comment)})
+	case run.Actions != nil && run.Actions.IsCancelable:
+		return c.Runs.Cancel(ctx, runID, tfe.RunCancelOptions{Comment: tfe.String(comment)})
+	}
+
+	return nil
+}
+
+// nextInterval returns the delay before the next read: the initial one after a status change,
+// or the current one increased by backoff factor but not greater than maximum
+func nextInterval(current, initial, maximum time.Duration, changed bool) time.Duration {
+	if changed || maximum <= initial {
+		return initial
+	}
+
+	next := time.Duration(float64(current) * backoffFactor)
+	if next > maximum {
+		next = maximum
+	}
+
+	return next
+}
+
+// ExitCode returns exit code describing the outcome of the run, policyFailed
+// marks errored runs which failed because of mandatory policies
+func ExitCode(run *tfe.Run, policyFailed bool) int {
+	if run == nil {
+		return ExitError
+	}
+
+	switch run.Status {
+	case tfe.RunApplied:
+		return ExitApplied
+	case tfe.RunErrored:
+		if policyFailed {
+			return ExitPolicyFailed
+		}
+		return ExitErrored
+	case tfe.RunDiscarded:
+		return ExitDiscarded
+	case tfe.RunCanceled:
+		return ExitCanceled
+	case tfe.RunPolicySoftFailed:
+		return ExitPolicyFailed
+	}
+
+	if run.HasChanges {
+		return ExitPlannedChanges
+	}
+
+	return ExitPlannedNoChanges
+}
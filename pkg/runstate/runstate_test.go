@@ -0,0 +1,300 @@
+package runstate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestIsFinal(t *testing.T) {
+	tests := []struct {
+		status tfe.RunStatus
+		want   bool
+	}{
+		{status: tfe.RunApplied, want: true},
+		{status: tfe.RunPlannedAndFinished, want: true},
+		{status: tfe.RunPlannedAndSaved, want: true},
+		{status: tfe.RunErrored, want: true},
+		{status: tfe.RunDiscarded, want: true},
+		{status: tfe.RunCanceled, want: true},
+		{status: tfe.RunPending, want: false},
+		{status: tfe.RunPlanning, want: false},
+		{status: tfe.RunPlanned, want: false},
+		{status: tfe.RunApplying, want: false},
+		{status: tfe.RunPolicySoftFailed, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := IsFinal(tt.status); got != tt.want {
+				t.Errorf("IsFinal(%q) = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsAction(t *testing.T) {
+	tests := []struct {
+		name string
+		run  *tfe.Run
+		want bool
+	}{
+		{
+			name: "nil run",
+			run:  nil,
+			want: false,
+		},
+		{
+			name: "planned and confirmable",
+			run:  &tfe.Run{Status: tfe.RunPlanned, Actions: &tfe.RunActions{IsConfirmable: true}},
+			want: true,
+		},
+		{
+			name: "planned and confirmable with auto apply",
+			run:  &tfe.Run{Status: tfe.RunPlanned, AutoApply: true, Actions: &tfe.RunActions{IsConfirmable: true}},
+			want: false,
+		},
+		{
+			name: "policy soft failed",
+			run:  &tfe.Run{Status: tfe.RunPolicySoftFailed},
+			want: true,
+		},
+		{
+			name: "awaiting run task decision",
+			run:  &tfe.Run{Status: tfe.RunPostPlanAwaitingDecision},
+			want: true,
+		},
+		{
+			name: "planning without actions",
+			run:  &tfe.Run{Status: tfe.RunPlanning},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeedsAction(tt.run); got != tt.want {
+				t.Errorf("NeedsAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	got := URL("app.terraform.io", "ealebed", "gitlab-tfc-demo", "run-123")
+	want := "https://app.terraform.io/app/ealebed/workspaces/gitlab-tfc-demo/runs/run-123"
+	if got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+}
+
+func TestPlanSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		plan *tfe.Plan
+		want string
+	}{
+		{name: "nil plan", plan: nil, want: "unknown"},
+		{name: "no changes", plan: &tfe.Plan{}, want: "no changes"},
+		{
+			name: "changes",
+			plan: &tfe.Plan{HasChanges: true, ResourceAdditions: 2, ResourceChanges: 1, ResourceDestructions: 3},
+			want: "2 to add, 1 to change, 3 to destroy",
+		},
+		{
+			name: "imports",
+			plan: &tfe.Plan{HasChanges: true, ResourceImports: 1, ResourceAdditions: 1},
+			want: "1 to import, 1 to add, 0 to change, 0 to destroy",
+		},
+		{
+			name: "output only changes",
+			plan: &tfe.Plan{HasChanges: true},
+			want: "0 to add, 0 to change, 0 to destroy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlanSummary(tt.plan); got != tt.want {
+				t.Errorf("PlanSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuration(t *testing.T) {
+	created := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	now := created.Add(10 * time.Minute)
+
+	tests := []struct {
+		name string
+		run  *tfe.Run
+		want time.Duration
+	}{
+		{name: "nil run", run: nil, want: 0},
+		{
+			name: "applied run",
+			run: &tfe.Run{
+				Status:    tfe.RunApplied,
+				CreatedAt: created,
+				StatusTimestamps: &tfe.RunStatusTimestamps{
+					PlannedAt: created.Add(time.Minute),
+					AppliedAt: created.Add(3*time.Minute + 400*time.Millisecond),
+				},
+			},
+			want: 3 * time.Minute,
+		},
+		{
+			name: "running run",
+			run:  &tfe.Run{Status: tfe.RunApplying, CreatedAt: created, StatusTimestamps: &tfe.RunStatusTimestamps{}},
+			want: 10 * time.Minute,
+		},
+		{
+			name: "final run without timestamps",
+			run:  &tfe.Run{Status: tfe.RunErrored, CreatedAt: created},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Duration(tt.run, now); got != tt.want {
+				t.Errorf("Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	created := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	run := &tfe.Run{
+		Status:    tfe.RunApplied,
+		CreatedAt: created,
+		StatusTimestamps: &tfe.RunStatusTimestamps{
+			PlanQueuedAt: created,
+			PlanningAt:   created.Add(10 * time.Second),
+			PlannedAt:    created.Add(time.Minute),
+			ConfirmedAt:  created.Add(5 * time.Minute),
+			AppliedAt:    created.Add(7 * time.Minute),
+			ApplyingAt:   created.Add(6 * time.Minute),
+		},
+	}
+
+	want := []StatusChange{
+		{Status: tfe.RunPending, At: created},
+		{Status: tfe.RunPlanQueued, At: created},
+		{Status: tfe.RunPlanning, At: created.Add(10 * time.Second)},
+		{Status: tfe.RunPlanned, At: created.Add(time.Minute)},
+		{Status: tfe.RunConfirmed, At: created.Add(5 * time.Minute)},
+		{Status: tfe.RunApplying, At: created.Add(6 * time.Minute)},
+		{Status: tfe.RunApplied, At: created.Add(7 * time.Minute)},
+	}
+	if got := Timeline(run); !reflect.DeepEqual(got, want) {
+		t.Errorf("Timeline() = %v, want %v", got, want)
+	}
+
+	if got := Timeline(&tfe.Run{CreatedAt: created}); len(got) != 1 {
+		t.Errorf("Timeline() without timestamps = %v, want only creation", got)
+	}
+	if got := Timeline(nil); got != nil {
+		t.Errorf("Timeline(nil) = %v, want nil", got)
+	}
+}
+
+func TestWaitingSince(t *testing.T) {
+	created := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		run  *tfe.Run
+		want time.Time
+	}{
+		{name: "nil run", run: nil, want: time.Time{}},
+		{name: "pending run", run: &tfe.Run{Status: tfe.RunPending, CreatedAt: created}, want: created},
+		{
+			name: "plan queued run",
+			run: &tfe.Run{
+				Status:           tfe.RunPlanQueued,
+				CreatedAt:        created,
+				StatusTimestamps: &tfe.RunStatusTimestamps{PlanQueuedAt: created.Add(time.Minute)},
+			},
+			want: created.Add(time.Minute),
+		},
+		{
+			name: "apply queued run without apply timestamp",
+			run: &tfe.Run{
+				Status:           tfe.RunApplyQueued,
+				CreatedAt:        created,
+				StatusTimestamps: &tfe.RunStatusTimestamps{PlanQueuedAt: created.Add(time.Minute), ConfirmedAt: created.Add(5 * time.Minute)},
+			},
+			want: created.Add(5 * time.Minute),
+		},
+		{name: "active run", run: &tfe.Run{Status: tfe.RunPlanning, CreatedAt: created}, want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WaitingSince(tt.run); !got.Equal(tt.want) {
+				t.Errorf("WaitingSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		current time.Duration
+		maximum time.Duration
+		changed bool
+		want    time.Duration
+	}{
+		{name: "no backoff", current: 5 * time.Second, maximum: 0, want: 5 * time.Second},
+		{name: "grows", current: 4 * time.Second, maximum: time.Minute, want: 6 * time.Second},
+		{name: "limited by maximum", current: 50 * time.Second, maximum: time.Minute, want: time.Minute},
+		{name: "reset on status change", current: 50 * time.Second, maximum: time.Minute, changed: true, want: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initial := 4 * time.Second
+			if tt.maximum == 0 {
+				initial = 5 * time.Second
+			}
+			if got := nextInterval(tt.current, initial, tt.maximum, tt.changed); got != tt.want {
+				t.Errorf("nextInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		run          *tfe.Run
+		policyFailed bool
+		want         int
+	}{
+		{name: "nil run", run: nil, want: ExitError},
+		{name: "applied", run: &tfe.Run{Status: tfe.RunApplied}, want: ExitApplied},
+		{name: "planned with changes", run: &tfe.Run{Status: tfe.RunPlanned, HasChanges: true}, want: ExitPlannedChanges},
+		{name: "plan only with changes", run: &tfe.Run{Status: tfe.RunPlannedAndFinished, HasChanges: true}, want: ExitPlannedChanges},
+		{name: "planned no changes", run: &tfe.Run{Status: tfe.RunPlannedAndFinished}, want: ExitPlannedNoChanges},
+		{name: "errored", run: &tfe.Run{Status: tfe.RunErrored}, want: ExitErrored},
+		{name: "errored by policy", run: &tfe.Run{Status: tfe.RunErrored}, policyFailed: true, want: ExitPolicyFailed},
+		{name: "policy soft failed", run: &tfe.Run{Status: tfe.RunPolicySoftFailed, HasChanges: true}, want: ExitPolicyFailed},
+		{name: "discarded", run: &tfe.Run{Status: tfe.RunDiscarded}, want: ExitDiscarded},
+		{name: "canceled", run: &tfe.Run{Status: tfe.RunCanceled}, want: ExitCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.run, tt.policyFailed); got != tt.want {
+				t.Errorf("ExitCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selector resolves terraform workspaces matching a label-like selector,
+// e.g. 'tag=prod,project=payments,name=app-*'.
+package selector
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// pageSize is the maximum page size supported by the Terraform Enterprise API
+const pageSize = 100
+
+// Selector represents parsed workspace selector. Workspace must have all tags
+// and none of excluded tags, while names and projects are alternatives.
+type Selector struct {
+	Names       []string
+	Tags        []string
+	ExcludeTags []string
+	Projects    []string
+}
+
+// Parse parses selector string of comma separated 'key=value' pairs.
+// Supported keys are 'name' (glob pattern), 'tag', '!tag' (excluded tag) and 'project'.
+func Parse(input string) (*Selector, error) {
+	s := &Selector{}
+
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid selector '%s', expected 'key=value'", pair)
+		}
+
+		switch key {
+		case "name":
+			if _, err := path.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid name pattern '%s': %v", value, err)
+			}
+			s.Names = append(s.Names, value)
+		case "tag":
+			s.Tags = append(s.Tags, value)
+		case "!tag":
+			s.ExcludeTags = append(s.ExcludeTags, value)
+		case "project":
+			s.Projects = append(s.Projects, value)
+		default:
+			return nil, fmt.Errorf("unknown selector key '%s', supported keys are 'name', 'tag', '!tag' and 'project'", key)
+		}
+	}
+
+	if s.IsEmpty() {
+		return nil, fmt.Errorf("selector '%s' doesn't contain any conditions", input)
+	}
+
+	return s, nil
+}
+
+// ParseOptional parses selector string like Parse, but returns an empty selector
+// matching all the workspaces if input is empty.
+func ParseOptional(input string) (*Selector, error) {
+	if strings.TrimSpace(input) == "" {
+		return &Selector{}, nil
+	}
+
+	return Parse(input)
+}
+
+// IsEmpty returns true if selector has no conditions
+func (s *Selector) IsEmpty() bool {
+	return len(s.Names) == 0 && len(s.Tags) == 0 && len(s.ExcludeTags) == 0 && len(s.Projects) == 0
+}
+
+// Match returns true if the workspace matches the selector
+func (s *Selector) Match(workspace *tfe.Workspace) bool {
+	if workspace == nil {
+		return false
+	}
+
+	if len(s.Names) > 0 {
+		matched := false
+		for _, pattern := range s.Names {
+			if ok, _ := path.Match(pattern, workspace.Name); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, tag := range s.Tags {
+		if !contains(workspace.TagNames, tag) {
+			return false
+		}
+	}
+
+	for _, tag := range s.ExcludeTags {
+		if contains(workspace.TagNames, tag) {
+			return false
+		}
+	}
+
+	if len(s.Projects) > 0 {
+		if workspace.Project == nil {
+			return false
+		}
+		if !contains(s.Projects, workspace.Project.Name) && !contains(s.Projects, workspace.Project.ID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListOptions returns workspace list options narrowing results on the server side
+func (s *Selector) ListOptions() *tfe.WorkspaceListOptions {
+	return &tfe.WorkspaceListOptions{
+		ListOptions: tfe.ListOptions{PageSize: pageSize},
+		Tags:        strings.Join(s.Tags, ","),
+		ExcludeTags: strings.Join(s.ExcludeTags, ","),
+		Include:     []tfe.WSIncludeOpt{tfe.WSProject},
+	}
+}
+
+// Resolve lists all the workspaces within an organization matching the selector
+func Resolve(ctx context.Context, c *tfe.Client, organization string, s *Selector) ([]*tfe.Workspace, error) {
+	var workspaces []*tfe.Workspace
+
+	listOptions := s.ListOptions()
+	for {
+		workspaceList, err := c.Workspaces.List(ctx, organization, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, workspace := range workspaceList.Items {
+			if s.Match(workspace) {
+				workspaces = append(workspaces, workspace)
+			}
+		}
+
+		if workspaceList.Pagination == nil || workspaceList.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = workspaceList.NextPage
+	}
+
+	return workspaces, nil
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Selector
+		wantErr bool
+	}{
+		{
+			name:  "tag and project",
+			input: "tag=prod,project=payments",
+			want:  &Selector{Tags: []string{"prod"}, Projects: []string{"payments"}},
+		},
+		{
+			name:  "name pattern with spaces",
+			input: " name = app-* , tag=prod ",
+			want:  &Selector{Names: []string{"app-*"}, Tags: []string{"prod"}},
+		},
+		{
+			name:  "excluded tag",
+			input: "tag=prod,!tag=legacy",
+			want:  &Selector{Tags: []string{"prod"}, ExcludeTags: []string{"legacy"}},
+		},
+		{
+			name:  "repeated keys",
+			input: "tag=prod,tag=eu,name=a,name=b",
+			want:  &Selector{Names: []string{"a", "b"}, Tags: []string{"prod", "eu"}},
+		},
+		{
+			name:    "empty selector",
+			input:   "",
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			input:   "tag=",
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			input:   "prod",
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			input:   "owner=me",
+			wantErr: true,
+		},
+		{
+			name:    "invalid name pattern",
+			input:   "name=[",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOptional(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *Selector
+		wantErr bool
+	}{
+		{
+			name:  "empty selector",
+			input: "",
+			want:  &Selector{},
+		},
+		{
+			name:  "blank selector",
+			input: "  ",
+			want:  &Selector{},
+		},
+		{
+			name:  "tag",
+			input: "tag=prod",
+			want:  &Selector{Tags: []string{"prod"}},
+		},
+		{
+			name:    "invalid selector",
+			input:   "prod",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOptional(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOptional() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOptional() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelector_Match(t *testing.T) {
+	workspace := &tfe.Workspace{
+		Name:     "app-payments-prod",
+		TagNames: []string{"prod", "eu"},
+		Project:  &tfe.Project{ID: "prj-1", Name: "payments"},
+	}
+
+	tests := []struct {
+		name      string
+		selector  *Selector
+		workspace *tfe.Workspace
+		want      bool
+	}{
+		{
+			name:      "all conditions match",
+			selector:  &Selector{Names: []string{"app-*"}, Tags: []string{"prod"}, Projects: []string{"payments"}},
+			workspace: workspace,
+			want:      true,
+		},
+		{
+			name:      "one of names matches",
+			selector:  &Selector{Names: []string{"db-*", "app-*"}},
+			workspace: workspace,
+			want:      true,
+		},
+		{
+			name:      "name doesn't match",
+			selector:  &Selector{Names: []string{"db-*"}},
+			workspace: workspace,
+			want:      false,
+		},
+		{
+			name:      "missing tag",
+			selector:  &Selector{Tags: []string{"prod", "us"}},
+			workspace: workspace,
+			want:      false,
+		},
+		{
+			name:      "excluded tag present",
+			selector:  &Selector{ExcludeTags: []string{"eu"}},
+			workspace: workspace,
+			want:      false,
+		},
+		{
+			name:      "project matched by ID",
+			selector:  &Selector{Projects: []string{"prj-1"}},
+			workspace: workspace,
+			want:      true,
+		},
+		{
+			name:      "project doesn't match",
+			selector:  &Selector{Projects: []string{"platform"}},
+			workspace: workspace,
+			want:      false,
+		},
+		{
+			name:      "workspace without project",
+			selector:  &Selector{Projects: []string{"payments"}},
+			workspace: &tfe.Workspace{Name: "app"},
+			want:      false,
+		},
+		{
+			name:      "nil workspace",
+			selector:  &Selector{Tags: []string{"prod"}},
+			workspace: nil,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.Match(tt.workspace); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelector_ListOptions(t *testing.T) {
+	s := &Selector{Tags: []string{"prod", "eu"}, ExcludeTags: []string{"legacy"}}
+
+	got := s.ListOptions()
+	if got.Tags != "prod,eu" {
+		t.Errorf("ListOptions().Tags = %q, want %q", got.Tags, "prod,eu")
+	}
+	if got.ExcludeTags != "legacy" {
+		t.Errorf("ListOptions().ExcludeTags = %q, want %q", got.ExcludeTags, "legacy")
+	}
+	if got.PageSize != pageSize {
+		t.Errorf("ListOptions().PageSize = %d, want %d", got.PageSize, pageSize)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// maxMessageLength limits the length of the result message shown in the run
+const maxMessageLength = 500
+
+// CommandDecider returns decider running the local command for each request.
+// The request is passed as JSON on stdin and as TFC_* environment variables,
+// zero exit code passes the task, the last line of the command output becomes the result message.
+func CommandDecider(command []string, timeout time.Duration) Decider {
+	return func(ctx context.Context, request *tfe.RunTaskRequest) Result {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		payload, err := json.Marshal(request)
+		if err != nil {
+			return Result{Status: tfe.TaskFailed, Message: err.Error()}
+		}
+
+		// #nosec G204 -- the command is configured by the user running the server
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), requestEnv(request)...)
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		// Don't wait for output of processes started by the command after it's killed on timeout
+		cmd.WaitDelay = time.Second
+
+		err = cmd.Run()
+		message := lastLine(out.String())
+
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			return Result{Status: tfe.TaskPassed, Message: message}
+		case ctx.Err() != nil:
+			return Result{Status: tfe.TaskFailed, Message: fmt.Sprintf("command timed out after %s", timeout)}
+		case errors.As(err, &exitErr):
+			if message == "" {
+				message = fmt.Sprintf("command exited with code %d", exitErr.ExitCode())
+			}
+			return Result{Status: tfe.TaskFailed, Message: message}
+		default:
+			return Result{Status: tfe.TaskFailed, Message: err.Error()}
+		}
+	}
+}
+
+// requestEnv returns the request fields as environment variables
+func requestEnv(request *tfe.RunTaskRequest) []string {
+	return []string{
+		"TFC_ACCESS_TOKEN=" + request.AccessToken,
+		"TFC_ORGANIZATION_NAME=" + request.OrganizationName,
+		"TFC_WORKSPACE_NAME=" + request.WorkspaceName,
+		"TFC_WORKSPACE_ID=" + request.WorkspaceID,
+		"TFC_RUN_ID=" + request.RunID,
+		"TFC_RUN_APP_URL=" + request.RunAppURL,
+		"TFC_STAGE=" + request.Stage,
+		"TFC_IS_SPECULATIVE=" + fmt.Sprint(request.IsSpeculative),
+		"TFC_PLAN_JSON_API_URL=" + request.PlanJSONAPIURL,
+		"TFC_CONFIGURATION_VERSION_DOWNLOAD_URL=" + request.ConfigurationVersionDownloadURL,
+	}
+}
+
+// lastLine returns the last non-empty line of the output truncated to the maximum message length
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+	if len(line) > maxMessageLength {
+		line = line[:maxMessageLength]
+	}
+	return line
+}
@@ -0,0 +1,51 @@
+package taskserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestCommandDecider(t *testing.T) {
+	request := &tfe.RunTaskRequest{RunID: "run-1", WorkspaceName: "app", Stage: "post_plan"}
+
+	tests := []struct {
+		name        string
+		script      string
+		timeout     time.Duration
+		wantStatus  tfe.TaskResultStatus
+		wantMessage string
+	}{
+		{name: "pass", script: `echo "scanning $TFC_WORKSPACE_NAME"; echo "no issues in $TFC_RUN_ID"`, wantStatus: tfe.TaskPassed, wantMessage: "no issues in run-1"},
+		{name: "fail", script: `echo "2 issues found"; exit 1`, wantStatus: tfe.TaskFailed, wantMessage: "2 issues found"},
+		{name: "fail silently", script: `exit 3`, wantStatus: tfe.TaskFailed, wantMessage: "command exited with code 3"},
+		{name: "stdin", script: `grep -q '"stage":"post_plan"'`, wantStatus: tfe.TaskPassed},
+		{name: "timeout", script: `sleep 5`, timeout: 100 * time.Millisecond, wantStatus: tfe.TaskFailed, wantMessage: "command timed out after 100ms"},
+	}
+
+	for _, tt := range tests {
+		timeout := tt.timeout
+		if timeout == 0 {
+			timeout = 10 * time.Second
+		}
+		result := CommandDecider([]string{"sh", "-c", tt.script}, timeout)(context.Background(), request)
+		if result.Status != tt.wantStatus || result.Message != tt.wantMessage {
+			t.Errorf("%s: result = %+v, want status %s and message %q", tt.name, result, tt.wantStatus, tt.wantMessage)
+		}
+	}
+}
+
+func TestLastLine(t *testing.T) {
+	if got := lastLine("first\nsecond\n\n"); got != "second" {
+		t.Errorf("lastLine() = %q, want %q", got, "second")
+	}
+	if got := lastLine(""); got != "" {
+		t.Errorf("lastLine() = %q, want empty", got)
+	}
+	if got := lastLine(strings.Repeat("x", 600)); len(got) != maxMessageLength {
+		t.Errorf("lastLine() length = %d, want %d", len(got), maxMessageLength)
+	}
+}
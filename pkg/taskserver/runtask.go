@@ -0,0 +1,191 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package taskserver implements the run task integration protocol of Terraform Enterprise (Cloud):
+// receiving signed run task requests and sending task results back via callback.
+//
+// TFE API docs: https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run-tasks/run-tasks-integration
+package taskserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+const (
+	// SignatureHeader is the header with HMAC-SHA512 signature of the request body
+	SignatureHeader = "X-TFC-Task-Signature"
+	// verificationToken is the access token of the test request sent when a run task is created or updated
+	verificationToken = "verification-token"
+	// maxRequestSize limits the size of accepted request body
+	maxRequestSize = 1 << 20
+)
+
+// Result is the outcome of a run task
+type Result struct {
+	Status  tfe.TaskResultStatus
+	Message string
+	URL     string
+}
+
+// Decider decides the outcome of a run task request
+type Decider func(ctx context.Context, request *tfe.RunTaskRequest) Result
+
+// Notifier delivers the outcome of a run task request
+type Notifier func(ctx context.Context, request *tfe.RunTaskRequest, result Result) error
+
+// Handler serves run task requests: verifies and acknowledges them,
+// then decides the outcome and notifies about it in background
+type Handler struct {
+	hmacKey string
+	decide  Decider
+	notify  Notifier
+	logf    func(format string, args ...any)
+	wg      sync.WaitGroup
+}
+
+// NewHandler returns new run task handler, requests are not verified if hmacKey is empty
+func NewHandler(hmacKey string, decide Decider, notify Notifier, logf func(format string, args ...any)) *Handler {
+	return &Handler{
+		hmacKey: hmacKey,
+		decide:  decide,
+		notify:  notify,
+		logf:    logf,
+	}
+}
+
+// Sign returns hex encoded HMAC-SHA512 signature of the body
+func Sign(body []byte, key string) string {
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of the body
+func Verify(body []byte, signature, key string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha512.New, []byte(key))
+	mac.Write(body)
+	return hmac.Equal(expected, mac.Sum(nil))
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	if h.hmacKey != "" && !Verify(body, r.Header.Get(SignatureHeader), h.hmacKey) {
+		h.logf("rejected request with invalid signature from %s", r.RemoteAddr)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var request tfe.RunTaskRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, "invalid run task request", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if request.AccessToken == verificationToken {
+		h.logf("verification request received from organization '%s'", request.OrganizationName)
+		return
+	}
+
+	h.logf("run '%s' of workspace '%s' received in stage '%s'", request.RunID, request.WorkspaceName, request.Stage)
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		h.process(&request)
+	}()
+}
+
+// Wait blocks until all the received requests are processed
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+func (h *Handler) process(request *tfe.RunTaskRequest) {
+	ctx := context.Background()
+
+	result := h.decide(ctx, request)
+	h.logf("run '%s' %s: %s", request.RunID, result.Status, result.Message)
+
+	if err := h.notify(ctx, request, result); err != nil {
+		h.logf("failed to send result of run '%s': %v", request.RunID, err)
+	}
+}
+
+// Callback sends the result to the callback URL of the run task request
+func Callback(ctx context.Context, client *http.Client, request *tfe.RunTaskRequest, result Result) error {
+	attributes := map[string]string{"status": string(result.Status)}
+	if result.Message != "" {
+		attributes["message"] = result.Message
+	}
+	if result.URL != "" {
+		attributes["url"] = result.URL
+	}
+	body, err := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"type":       "task-results",
+			"attributes": attributes,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, request.TaskResultCallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	req.Header.Set("Authorization", "Bearer "+request.AccessToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("callback returned status %s", resp.Status)
+	}
+
+	return nil
+}
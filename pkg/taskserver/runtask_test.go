@@ -0,0 +1,122 @@
+package taskserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"run_id":"run-1"}`)
+	signature := Sign(body, "secret")
+
+	if !Verify(body, signature, "secret") {
+		t.Error("Verify() = false for valid signature")
+	}
+	if Verify(body, signature, "other") {
+		t.Error("Verify() = true for wrong key")
+	}
+	if Verify([]byte(`{"run_id":"run-2"}`), signature, "secret") {
+		t.Error("Verify() = true for modified body")
+	}
+	if Verify(body, "not-hex", "secret") {
+		t.Error("Verify() = true for malformed signature")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		notified []Result
+	)
+	decide := func(_ context.Context, request *tfe.RunTaskRequest) Result {
+		return Result{Status: tfe.TaskPassed, Message: "checked " + request.RunID}
+	}
+	notify := func(_ context.Context, _ *tfe.RunTaskRequest, result Result) error {
+		mu.Lock()
+		defer mu.Unlock()
+		notified = append(notified, result)
+		return nil
+	}
+	handler := NewHandler("secret", decide, notify, func(string, ...any) {})
+
+	send := func(method, body, signature string) int {
+		req := httptest.NewRequest(method, "/", strings.NewReader(body))
+		req.Header.Set(SignatureHeader, signature)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	run := `{"access_token":"token","run_id":"run-1","stage":"post_plan"}`
+	verification := `{"access_token":"verification-token","organization_name":"org"}`
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		signature string
+		want      int
+	}{
+		{name: "wrong method", method: http.MethodGet, body: run, signature: Sign([]byte(run), "secret"), want: http.StatusMethodNotAllowed},
+		{name: "invalid signature", method: http.MethodPost, body: run, signature: Sign([]byte(run), "other"), want: http.StatusUnauthorized},
+		{name: "invalid json", method: http.MethodPost, body: "{", signature: Sign([]byte("{"), "secret"), want: http.StatusBadRequest},
+		{name: "verification", method: http.MethodPost, body: verification, signature: Sign([]byte(verification), "secret"), want: http.StatusOK},
+		{name: "run", method: http.MethodPost, body: run, signature: Sign([]byte(run), "secret"), want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got := send(tt.method, tt.body, tt.signature); got != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	handler.Wait()
+	if len(notified) != 1 || notified[0].Message != "checked run-1" {
+		t.Errorf("notified = %+v, want single result for run-1", notified)
+	}
+}
+
+func TestCallback(t *testing.T) {
+	var (
+		gotAuth string
+		gotBody map[string]map[string]any
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/task-results/1/callback" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.NewDecoder(bytes.NewReader(data)).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	request := &tfe.RunTaskRequest{AccessToken: "token", TaskResultCallbackURL: server.URL + "/task-results/1/callback"}
+	err := Callback(context.Background(), server.Client(), request, Result{Status: tfe.TaskFailed, Message: "2 issues found"})
+	if err != nil {
+		t.Fatalf("Callback() unexpected error: %v", err)
+	}
+
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer token")
+	}
+	attributes, _ := gotBody["data"]["attributes"].(map[string]any)
+	if gotBody["data"]["type"] != "task-results" || attributes["status"] != "failed" || attributes["message"] != "2 issues found" {
+		t.Errorf("callback body = %v", gotBody)
+	}
+
+	request.TaskResultCallbackURL = server.URL + "/missing"
+	if err := Callback(context.Background(), server.Client(), request, Result{Status: tfe.TaskPassed}); err == nil {
+		t.Error("Callback() should return error for unsuccessful response")
+	}
+}
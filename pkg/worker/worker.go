@@ -0,0 +1,86 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package worker runs a function over a set of items with a bounded number of
+// concurrent workers and an optional rate limit.
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultWorkers is the default number of concurrent workers
+const DefaultWorkers = 4
+
+// Options represents options for the worker pool
+type Options struct {
+	// Workers is the maximum number of concurrently processed items, DefaultWorkers if not set
+	Workers int
+	// Rate is the maximum number of started items per second, unlimited if not set
+	Rate float64
+}
+
+// Run calls fn for every item and returns errors in the same order as items.
+// Items which were not started because the context was canceled get the context error.
+func Run[T any](ctx context.Context, items []T, options Options, fn func(context.Context, T) error) []error {
+	errs := make([]error, len(items))
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var throttle <-chan time.Time
+	if options.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / options.Rate))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = fn(ctx, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		if throttle != nil && i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-throttle:
+			}
+		}
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6}
+	errOdd := errors.New("odd")
+
+	errs := Run(context.Background(), items, Options{Workers: 3}, func(_ context.Context, i int) error {
+		if i%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	if len(errs) != len(items) {
+		t.Fatalf("Run() returned %d errors, want %d", len(errs), len(items))
+	}
+	for i, item := range items {
+		if item%2 == 1 && !errors.Is(errs[i], errOdd) {
+			t.Errorf("Run() error for item %d = %v, want %v", item, errs[i], errOdd)
+		}
+		if item%2 == 0 && errs[i] != nil {
+			t.Errorf("Run() error for item %d = %v, want nil", item, errs[i])
+		}
+	}
+}
+
+func TestRun_BoundedConcurrency(t *testing.T) {
+	items := make([]int, 20)
+	var running, maxRunning int32
+
+	Run(context.Background(), items, Options{Workers: 2}, func(_ context.Context, _ int) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	if maxRunning > 2 {
+		t.Errorf("Run() had %d concurrent workers, want at most 2", maxRunning)
+	}
+}
+
+func TestRun_Rate(t *testing.T) {
+	items := make([]int, 5)
+
+	start := time.Now()
+	Run(context.Background(), items, Options{Workers: 5, Rate: 100}, func(_ context.Context, _ int) error {
+		return nil
+	})
+
+	// 5 items at 100 per second need at least 4 intervals of 10ms
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Run() finished in %v, want rate limited to at least 40ms", elapsed)
+	}
+}
+
+func TestRun_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var called int32
+	errs := Run(ctx, []int{1, 2, 3}, Options{}, func(_ context.Context, _ int) error {
+		atomic.AddInt32(&called, 1)
+		return nil
+	})
+
+	if called != 0 {
+		t.Errorf("Run() called fn %d times for canceled context, want 0", called)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run() error for item %d = %v, want %v", i, err, context.Canceled)
+		}
+	}
+}
+
+func TestRun_NoItems(t *testing.T) {
+	errs := Run(context.Background(), []int{}, Options{}, func(_ context.Context, _ int) error {
+		return nil
+	})
+	if len(errs) != 0 {
+		t.Errorf("Run() returned %d errors for no items, want 0", len(errs))
+	}
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{name: "short yes", input: "y\n", want: true},
+		{name: "long yes", input: "yes\n", want: true},
+		{name: "upper case yes", input: "YES\n", want: true},
+		{name: "yes with spaces", input: "  y  \n", want: true},
+		{name: "yes without newline", input: "y", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty answer", input: "\n", want: false},
+		{name: "no input", input: "", want: false},
+		{name: "other answer", input: "sure\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := Confirm(strings.NewReader(tt.input), &out, "Proceed?")
+			if err != nil {
+				t.Fatalf("Confirm() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Confirm() = %v, want %v", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "Proceed? [y/N]") {
+				t.Errorf("Confirm() prompt = %q, want to contain question", out.String())
+			}
+		})
+	}
+}
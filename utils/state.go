@@ -0,0 +1,58 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// StateMeta represents fields identifying a terraform state
+type StateMeta struct {
+	Version int    `json:"version"`
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+// ParseStateMeta returns serial and lineage of a raw terraform state
+func ParseStateMeta(state []byte) (*StateMeta, error) {
+	meta := &StateMeta{}
+	if err := json.Unmarshal(state, meta); err != nil {
+		return nil, fmt.Errorf("failed to parse terraform state: %v", err)
+	}
+	if meta.Lineage == "" {
+		return nil, fmt.Errorf("terraform state has no lineage")
+	}
+
+	return meta, nil
+}
+
+// ReadVariableValues reads a JSON object with variable names and their values from the given file
+func ReadVariableValues(path string) (map[string]string, error) {
+	// #nosec G304 -- file path is provided by the user on purpose
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse variable values file '%s': %v", path, err)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseStateMeta(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		want    *StateMeta
+		wantErr bool
+	}{
+		{
+			name:  "valid state",
+			state: `{"version": 4, "terraform_version": "1.9.5", "serial": 42, "lineage": "3f1c-aa", "resources": []}`,
+			want:  &StateMeta{Version: 4, Serial: 42, Lineage: "3f1c-aa"},
+		},
+		{
+			name:    "missing lineage",
+			state:   `{"version": 4, "serial": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			state:   `{"version":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStateMeta([]byte(tt.state))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStateMeta() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStateMeta() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadVariableValues(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`{"db_password": "secret", "API_KEY": "key"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"db_password": 1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadVariableValues(valid)
+	if err != nil {
+		t.Fatalf("ReadVariableValues() unexpected error: %v", err)
+	}
+	want := map[string]string{"db_password": "secret", "API_KEY": "key"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadVariableValues() = %v, want %v", got, want)
+	}
+
+	if _, err := ReadVariableValues(invalid); err == nil {
+		t.Error("ReadVariableValues() should return error for non-string values")
+	}
+	if _, err := ReadVariableValues(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ReadVariableValues() should return error for missing file")
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// ParseTags splits tags into plain tag names and 'key=value' tag bindings
+func ParseTags(items []string) ([]string, []*tfe.TagBinding, error) {
+	var names []string
+	var bindings []*tfe.TagBinding
+
+	for _, item := range items {
+		key, value, isBinding := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, nil, fmt.Errorf("invalid tag '%s', expected 'name' or 'key=value'", item)
+		}
+
+		if isBinding {
+			bindings = append(bindings, &tfe.TagBinding{Key: key, Value: strings.TrimSpace(value)})
+		} else {
+			names = append(names, key)
+		}
+	}
+
+	return names, bindings, nil
+}
+
+// RemoveTagBindings returns tag bindings without removed ones. A removed binding
+// without value matches any value of the same key.
+func RemoveTagBindings(bindings, removed []*tfe.TagBinding) []*tfe.TagBinding {
+	var remaining []*tfe.TagBinding
+
+	for _, b := range bindings {
+		keep := true
+		for _, r := range removed {
+			if b.Key == r.Key && (r.Value == "" || b.Value == r.Value) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, &tfe.TagBinding{Key: b.Key, Value: b.Value})
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		items        []string
+		wantNames    []string
+		wantBindings []*tfe.TagBinding
+		wantErr      bool
+	}{
+		{
+			name:      "plain tags",
+			items:     []string{"prod", "payments"},
+			wantNames: []string{"prod", "payments"},
+		},
+		{
+			name:         "mixed tags and bindings",
+			items:        []string{"prod", "env=production", "team = payments "},
+			wantNames:    []string{"prod"},
+			wantBindings: []*tfe.TagBinding{{Key: "env", Value: "production"}, {Key: "team", Value: "payments"}},
+		},
+		{
+			name:         "binding without value",
+			items:        []string{"owner="},
+			wantBindings: []*tfe.TagBinding{{Key: "owner", Value: ""}},
+		},
+		{
+			name:    "empty key",
+			items:   []string{"=value"},
+			wantErr: true,
+		},
+		{
+			name:    "empty tag",
+			items:   []string{""},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, bindings, err := ParseTags(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("ParseTags() names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(bindings, tt.wantBindings) {
+				t.Errorf("ParseTags() bindings = %v, want %v", bindings, tt.wantBindings)
+			}
+		})
+	}
+}
+
+func TestRemoveTagBindings(t *testing.T) {
+	bindings := []*tfe.TagBinding{
+		{ID: "tb-1", Key: "env", Value: "prod"},
+		{ID: "tb-2", Key: "team", Value: "payments"},
+		{ID: "tb-3", Key: "owner", Value: "me"},
+	}
+
+	tests := []struct {
+		name    string
+		removed []*tfe.TagBinding
+		want    []*tfe.TagBinding
+	}{
+		{
+			name:    "remove by key",
+			removed: []*tfe.TagBinding{{Key: "env"}},
+			want:    []*tfe.TagBinding{{Key: "team", Value: "payments"}, {Key: "owner", Value: "me"}},
+		},
+		{
+			name:    "remove by key and value",
+			removed: []*tfe.TagBinding{{Key: "team", Value: "payments"}},
+			want:    []*tfe.TagBinding{{Key: "env", Value: "prod"}, {Key: "owner", Value: "me"}},
+		},
+		{
+			name:    "value doesn't match",
+			removed: []*tfe.TagBinding{{Key: "team", Value: "platform"}},
+			want:    []*tfe.TagBinding{{Key: "env", Value: "prod"}, {Key: "team", Value: "payments"}, {Key: "owner", Value: "me"}},
+		},
+		{
+			name:    "remove all",
+			removed: []*tfe.TagBinding{{Key: "env"}, {Key: "team"}, {Key: "owner"}},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RemoveTagBindings(bindings, tt.removed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RemoveTagBindings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
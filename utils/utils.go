@@ -19,6 +19,7 @@ package utils
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/go-tfe"
 )
@@ -67,3 +68,56 @@ func GetOAuthClientID(oauthClient *tfe.OAuthClientList, serviceProviderName stri
 
 	return ""
 }
+
+// GetTeamID returns team ID by given name
+func GetTeamID(teams *tfe.TeamList, teamName string) string {
+	if teams == nil {
+		return ""
+	}
+	for _, t := range teams.Items {
+		if t.Name == teamName {
+			return t.ID
+		}
+	}
+
+	return ""
+}
+
+// GetProjectID returns project ID by given name
+func GetProjectID(projects *tfe.ProjectList, projectName string) string {
+	if projects == nil {
+		return ""
+	}
+	for _, p := range projects.Items {
+		if p.Name == projectName {
+			return p.ID
+		}
+	}
+
+	return ""
+}
+
+// GetRunTaskID returns run task ID by given name
+func GetRunTaskID(runTasks *tfe.RunTaskList, runTaskName string) string {
+	if runTasks == nil {
+		return ""
+	}
+	for _, r := range runTasks.Items {
+		if r.Name == runTaskName {
+			return r.ID
+		}
+	}
+
+	return ""
+}
+
+// ValidateOneOf returns error if value of the named option is not one of allowed values
+func ValidateOneOf(name, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid %s '%s', allowed values: %s", name, value, strings.Join(allowed, ", "))
+}
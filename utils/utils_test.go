@@ -299,3 +299,175 @@ func TestGetOAuthClientID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTeamID(t *testing.T) {
+	tests := []struct {
+		name     string
+		teams    *tfe.TeamList
+		teamName string
+		want     string
+	}{
+		{
+			name: "team found",
+			teams: &tfe.TeamList{
+				Items: []*tfe.Team{
+					{ID: "team-1", Name: "owners"},
+					{ID: "team-2", Name: "developers"},
+				},
+			},
+			teamName: "developers",
+			want:     "team-2",
+		},
+		{
+			name: "team not found",
+			teams: &tfe.TeamList{
+				Items: []*tfe.Team{
+					{ID: "team-1", Name: "owners"},
+				},
+			},
+			teamName: "developers",
+			want:     "",
+		},
+		{
+			name:     "nil team list",
+			teams:    nil,
+			teamName: "owners",
+			want:     "",
+		},
+		{
+			name: "case sensitive match",
+			teams: &tfe.TeamList{
+				Items: []*tfe.Team{
+					{ID: "team-1", Name: "Owners"},
+					{ID: "team-2", Name: "owners"},
+				},
+			},
+			teamName: "owners",
+			want:     "team-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetTeamID(tt.teams, tt.teamName)
+			if got != tt.want {
+				t.Errorf("GetTeamID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProjectID(t *testing.T) {
+	tests := []struct {
+		name        string
+		projects    *tfe.ProjectList
+		projectName string
+		want        string
+	}{
+		{
+			name: "project found",
+			projects: &tfe.ProjectList{
+				Items: []*tfe.Project{
+					{ID: "prj-1", Name: "Default Project"},
+					{ID: "prj-2", Name: "platform"},
+				},
+			},
+			projectName: "platform",
+			want:        "prj-2",
+		},
+		{
+			name: "project not found",
+			projects: &tfe.ProjectList{
+				Items: []*tfe.Project{
+					{ID: "prj-1", Name: "Default Project"},
+				},
+			},
+			projectName: "platform",
+			want:        "",
+		},
+		{
+			name:        "nil project list",
+			projects:    nil,
+			projectName: "platform",
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetProjectID(tt.projects, tt.projectName)
+			if got != tt.want {
+				t.Errorf("GetProjectID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRunTaskID(t *testing.T) {
+	tests := []struct {
+		name        string
+		runTasks    *tfe.RunTaskList
+		runTaskName string
+		want        string
+	}{
+		{
+			name: "run task found",
+			runTasks: &tfe.RunTaskList{
+				Items: []*tfe.RunTask{
+					{ID: "task-1", Name: "tfsec"},
+					{ID: "task-2", Name: "checkov"},
+				},
+			},
+			runTaskName: "checkov",
+			want:        "task-2",
+		},
+		{
+			name: "run task not found",
+			runTasks: &tfe.RunTaskList{
+				Items: []*tfe.RunTask{
+					{ID: "task-1", Name: "tfsec"},
+				},
+			},
+			runTaskName: "checkov",
+			want:        "",
+		},
+		{
+			name:        "nil run task list",
+			runTasks:    nil,
+			runTaskName: "checkov",
+			want:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetRunTaskID(tt.runTasks, tt.runTaskName)
+			if got != tt.want {
+				t.Errorf("GetRunTaskID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOneOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		allowed []string
+		wantErr bool
+	}{
+		{name: "allowed value", value: "write", allowed: []string{"read", "write"}, wantErr: false},
+		{name: "not allowed value", value: "admin", allowed: []string{"read", "write"}, wantErr: true},
+		{name: "empty value", value: "", allowed: []string{"read"}, wantErr: true},
+		{name: "no allowed values", value: "read", allowed: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOneOf("access", tt.value, tt.allowed...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOneOf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2022 Yevhen Lebid ealebed@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+// hclNumber matches decimal HCL number literals, ParseFloat would also accept 'inf', 'NaN' and hex floats
+var hclNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// ParseRunVariables converts 'key=value' items into run-scoped variables. Values which
+// are already HCL literals (numbers, booleans, null, quoted strings, lists and maps) are
+// passed as is, any other value is quoted as HCL string.
+func ParseRunVariables(items []string) ([]*tfe.RunVariable, error) {
+	var variables []*tfe.RunVariable
+
+	for _, item := range items {
+		key, value, found := strings.Cut(item, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid variable '%s', expected 'key=value'", item)
+		}
+
+		variables = append(variables, &tfe.RunVariable{Key: key, Value: hclLiteral(value)})
+	}
+
+	return variables, nil
+}
+
+// hclLiteral returns value as is if it looks like HCL literal or quoted HCL string otherwise
+func hclLiteral(value string) string {
+	trimmed := strings.TrimSpace(value)
+
+	switch {
+	case trimmed == "true", trimmed == "false", trimmed == "null":
+		return trimmed
+	case strings.HasPrefix(trimmed, `"`), strings.HasPrefix(trimmed, "["), strings.HasPrefix(trimmed, "{"):
+		return trimmed
+	}
+	if hclNumber.MatchString(trimmed) {
+		return trimmed
+	}
+
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+
+	return quoted
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-tfe"
+)
+
+func TestParseRunVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []string
+		want    []*tfe.RunVariable
+		wantErr bool
+	}{
+		{
+			name:  "plain string is quoted",
+			items: []string{"region=us-east-1"},
+			want:  []*tfe.RunVariable{{Key: "region", Value: `"us-east-1"`}},
+		},
+		{
+			name:  "literals are passed as is",
+			items: []string{"count=3", "enabled=true", "ratio=0.5", "tags={env = \"prod\"}", "zones=[\"a\", \"b\"]", "name=\"quoted\""},
+			want: []*tfe.RunVariable{
+				{Key: "count", Value: "3"},
+				{Key: "enabled", Value: "true"},
+				{Key: "ratio", Value: "0.5"},
+				{Key: "tags", Value: `{env = "prod"}`},
+				{Key: "zones", Value: `["a", "b"]`},
+				{Key: "name", Value: `"quoted"`},
+			},
+		},
+		{
+			name:  "non-decimal numbers are quoted",
+			items: []string{"a=inf", "b=NaN", "c=0x1p-2", "d=-1.5e3", "e=1_000"},
+			want: []*tfe.RunVariable{
+				{Key: "a", Value: `"inf"`},
+				{Key: "b", Value: `"NaN"`},
+				{Key: "c", Value: `"0x1p-2"`},
+				{Key: "d", Value: "-1.5e3"},
+				{Key: "e", Value: `"1_000"`},
+			},
+		},
+		{
+			name:  "template sequences are escaped",
+			items: []string{"greeting=hello ${name} %{if}"},
+			want:  []*tfe.RunVariable{{Key: "greeting", Value: `"hello $${name} %%{if}"`}},
+		},
+		{
+			name:  "value with equal sign",
+			items: []string{"query=a=b"},
+			want:  []*tfe.RunVariable{{Key: "query", Value: `"a=b"`}},
+		},
+		{
+			name:  "empty value",
+			items: []string{"suffix="},
+			want:  []*tfe.RunVariable{{Key: "suffix", Value: `""`}},
+		},
+		{
+			name:    "missing value",
+			items:   []string{"region"},
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			items:   []string{"=value"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRunVariables(tt.items)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRunVariables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRunVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}